@@ -0,0 +1,226 @@
+package optional
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+)
+
+// Controls how aggregate functions treat empty inputs.
+type EmptyPolicy int
+
+const (
+	// Empty inputs are ignored.
+	SkipEmpty EmptyPolicy = iota
+	// Any empty input makes the result empty.
+	PropagateEmpty
+)
+
+// A Reducer function signature.
+//
+// Takes the accumulated value and the next value and returns the new
+// accumulated value.
+type Reducer func(T, T) T
+
+// Constraint satisfied by the integer and floating point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Returns an Optional describing the result of folding the present values of
+// opts into init with the given function, if non-nil. Nil optionals are
+// treated as empty.
+func Reduce(opts []*Optional, init T, f Reducer, p EmptyPolicy) *Optional {
+	return ReduceOptions(options(opts), init, func(acc T, t T) T {
+		return f(acc, t)
+	}, p).Optional()
+}
+
+// Returns an Optional describing the sum of the present values of opts, or an
+// empty Optional if no value is present. All values must be numbers of the
+// same type.
+func Sum(opts []*Optional, p EmptyPolicy) *Optional {
+	return reducePresent(options(opts), p, add).Optional()
+}
+
+// Returns an Optional describing the smallest present value of opts, or an
+// empty Optional if no value is present.
+//
+// Numbers and strings of the same type are compared by their natural ordering,
+// other values by their Cmpr method, see Interface.
+func Min(opts []*Optional, p EmptyPolicy) *Optional {
+	return MinOptions(options(opts), p).Optional()
+}
+
+// Returns an Optional describing the largest present value of opts, or an
+// empty Optional if no value is present. Values are ordered as by Min.
+func Max(opts []*Optional, p EmptyPolicy) *Optional {
+	return MaxOptions(options(opts), p).Optional()
+}
+
+// Returns an Optional describing the mean of the present values of opts as a
+// float64, or an empty Optional if no value is present. All values must be
+// numbers.
+func Average(opts []*Optional, p EmptyPolicy) *Optional {
+	return average(options(opts), p, toFloat).Optional()
+}
+
+// Returns an Option describing the result of folding the present values of
+// opts into init with the given function, if non-nil.
+func ReduceOptions[V, A any](opts []Option[V], init A, f func(A, V) A, p EmptyPolicy) Option[A] {
+	acc := init
+	for _, o := range opts {
+		if !o.present {
+			if p == PropagateEmpty {
+				return Option[A]{}
+			}
+			continue
+		}
+		acc = f(acc, o.v)
+	}
+	return OptionOfNilable(acc)
+}
+
+// Returns an Option describing the sum of the present values of opts, or an
+// empty Option if no value is present.
+func SumOptions[V Number](opts []Option[V], p EmptyPolicy) Option[V] {
+	return reducePresent(opts, p, func(a, b V) V {
+		return a + b
+	})
+}
+
+// Returns an Option describing the smallest present value of opts, or an empty
+// Option if no value is present. Values are ordered as by Min.
+func MinOptions[V any](opts []Option[V], p EmptyPolicy) Option[V] {
+	return reducePresent(opts, p, func(a, b V) V {
+		if compare("Min", a, b) > 0 {
+			return b
+		}
+		return a
+	})
+}
+
+// Returns an Option describing the largest present value of opts, or an empty
+// Option if no value is present. Values are ordered as by Min.
+func MaxOptions[V any](opts []Option[V], p EmptyPolicy) Option[V] {
+	return reducePresent(opts, p, func(a, b V) V {
+		if compare("Max", a, b) < 0 {
+			return b
+		}
+		return a
+	})
+}
+
+// Returns an Option describing the mean of the present values of opts, or an
+// empty Option if no value is present.
+func AverageOptions[V Number](opts []Option[V], p EmptyPolicy) Option[float64] {
+	return average(opts, p, func(v V) float64 {
+		return float64(v)
+	})
+}
+
+func options(opts []*Optional) []Option[T] {
+	os := make([]Option[T], len(opts))
+	for i, o := range opts {
+		if isPresent(o) {
+			os[i] = Option[T]{v: o.t, present: true}
+		}
+	}
+	return os
+}
+
+// Combines the present values of opts pairwise from the left. The result is
+// empty if no value is present.
+func reducePresent[V any](opts []Option[V], p EmptyPolicy, f func(V, V) V) Option[V] {
+	var acc Option[V]
+	for _, o := range opts {
+		switch {
+		case !o.present && p == PropagateEmpty:
+			return Option[V]{}
+		case !o.present:
+		case acc.present:
+			acc.v = f(acc.v, o.v)
+		default:
+			acc = o
+		}
+	}
+	return acc
+}
+
+func average[V any](opts []Option[V], p EmptyPolicy, f func(V) float64) Option[float64] {
+	var sum float64
+	n := 0
+	for _, o := range opts {
+		if !o.present {
+			if p == PropagateEmpty {
+				return Option[float64]{}
+			}
+			continue
+		}
+		sum += f(o.v)
+		n++
+	}
+	if n == 0 {
+		return Option[float64]{}
+	}
+	return OptionOf(sum / float64(n))
+}
+
+func add(a, b T) T {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+	if x.Type() != y.Type() {
+		panic(fmt.Sprintf("optional.Sum: mismatched types %v and %v", x.Type(), y.Type()))
+	}
+	r := reflect.New(x.Type()).Elem()
+	switch x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.SetInt(x.Int() + y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		r.SetUint(x.Uint() + y.Uint())
+	case reflect.Float32, reflect.Float64:
+		r.SetFloat(x.Float() + y.Float())
+	default:
+		panic(fmt.Sprintf("optional.Sum: %v is not a number", x.Type()))
+	}
+	return r.Interface()
+}
+
+func toFloat(t T) float64 {
+	v := reflect.ValueOf(t)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	panic(fmt.Sprintf("optional.Average: %v is not a number", v.Type()))
+}
+
+// Compares a and b by their natural ordering if they are numbers or strings of
+// the same type, otherwise by the Cmpr method of a. Panics if neither applies.
+func compare(fn string, a, b T) int {
+	x, y := reflect.ValueOf(a), reflect.ValueOf(b)
+	if x.Type() == y.Type() {
+		switch x.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(x.Int(), y.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr:
+			return cmp.Compare(x.Uint(), y.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(x.Float(), y.Float())
+		case reflect.String:
+			return cmp.Compare(x.String(), y.String())
+		}
+	}
+	if i, ok := a.(Interface); ok {
+		return i.Cmpr(b)
+	}
+	panic(fmt.Sprintf("optional.%s: %v has no ordering", fn, x.Type()))
+}
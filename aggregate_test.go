@@ -0,0 +1,217 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"testing"
+)
+
+func column() []*op.Optional {
+	return []*op.Optional{op.Of(3), op.Empty(), op.Of(1), nil, op.Of(2)}
+}
+
+func Test_Reduce(t *testing.T) {
+	t.Run("Reduce", Reduce_test)
+	t.Run("Reduce propagate empty", ReducePropagateEmpty_test)
+	t.Run("ReduceOptions", ReduceOptions_test)
+}
+
+func Reduce_test(t *testing.T) {
+	defer shouldNotPanic("optional.Reduce", t)
+
+	o := op.Reduce(column(), "", func(acc, t op.T) op.T {
+		return acc.(string) + string(rune('0'+t.(int)))
+	}, op.SkipEmpty)
+	if v := o.Get().(string); v != "312" {
+		t.Errorf("Expected `%v`, got `%v`", "312", v)
+	}
+}
+
+func ReducePropagateEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.Reduce", t)
+
+	o := op.Reduce(column(), 0, func(acc, t op.T) op.T {
+		return acc.(int) + t.(int)
+	}, op.PropagateEmpty)
+	if o.IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func ReduceOptions_test(t *testing.T) {
+	defer shouldNotPanic("optional.ReduceOptions", t)
+
+	opts := []op.Option[string]{op.OptionOf("a"), op.OptionEmpty[string](), op.OptionOf("bc")}
+	o := op.ReduceOptions(opts, 0, func(n int, s string) int {
+		return n + len(s)
+	}, op.SkipEmpty)
+	if v := o.Get(); v != 3 {
+		t.Errorf("Expected `%v`, got `%v`", 3, v)
+	}
+}
+
+func Test_Sum(t *testing.T) {
+	t.Run("Sum", Sum_test)
+	t.Run("Sum propagate empty", SumPropagateEmpty_test)
+	t.Run("Sum no values", SumNoValues_test)
+	t.Run("Sum mismatched types", SumMismatched_test)
+	t.Run("SumOptions", SumOptions_test)
+}
+
+func Sum_test(t *testing.T) {
+	defer shouldNotPanic("optional.Sum", t)
+
+	if v := op.Sum(column(), op.SkipEmpty).Get().(int); v != 6 {
+		t.Errorf("Expected `%v`, got `%v`", 6, v)
+	}
+	o := op.Sum([]*op.Optional{op.Of(1.5), op.Of(2.25)}, op.SkipEmpty)
+	if v := o.Get().(float64); v != 3.75 {
+		t.Errorf("Expected `%v`, got `%v`", 3.75, v)
+	}
+}
+
+func SumPropagateEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.Sum", t)
+
+	if op.Sum(column(), op.PropagateEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	o := op.Sum([]*op.Optional{op.Of(uint8(1)), op.Of(uint8(2))}, op.PropagateEmpty)
+	if v := o.Get().(uint8); v != 3 {
+		t.Errorf("Expected `%v`, got `%v`", 3, v)
+	}
+}
+
+func SumNoValues_test(t *testing.T) {
+	defer shouldNotPanic("optional.Sum", t)
+
+	if op.Sum(nil, op.SkipEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	if op.Sum([]*op.Optional{op.Empty()}, op.SkipEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func SumMismatched_test(t *testing.T) {
+	defer shouldPanic("optional.Sum with mismatched types", t)
+
+	op.Sum([]*op.Optional{op.Of(1), op.Of(1.0)}, op.SkipEmpty)
+	t.Fatal("this code should not be reachable.")
+}
+
+func SumOptions_test(t *testing.T) {
+	defer shouldNotPanic("optional.SumOptions", t)
+
+	opts := []op.Option[int]{op.OptionOf(1), op.OptionEmpty[int](), op.OptionOf(2)}
+	if v := op.SumOptions(opts, op.SkipEmpty).Get(); v != 3 {
+		t.Errorf("Expected `%v`, got `%v`", 3, v)
+	}
+	if op.SumOptions(opts, op.PropagateEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func Test_MinMax(t *testing.T) {
+	t.Run("Min", Min_test)
+	t.Run("Max", Max_test)
+	t.Run("Min strings", MinStrings_test)
+	t.Run("Min Cmpr", MinCmpr_test)
+	t.Run("Min no ordering", MinNoOrdering_test)
+	t.Run("MinOptions and MaxOptions", MinMaxOptions_test)
+}
+
+func Min_test(t *testing.T) {
+	defer shouldNotPanic("optional.Min", t)
+
+	if v := op.Min(column(), op.SkipEmpty).Get().(int); v != 1 {
+		t.Errorf("Expected `%v`, got `%v`", 1, v)
+	}
+	if op.Min(column(), op.PropagateEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func Max_test(t *testing.T) {
+	defer shouldNotPanic("optional.Max", t)
+
+	if v := op.Max(column(), op.SkipEmpty).Get().(int); v != 3 {
+		t.Errorf("Expected `%v`, got `%v`", 3, v)
+	}
+}
+
+func MinStrings_test(t *testing.T) {
+	defer shouldNotPanic("optional.Min", t)
+
+	o := op.Min([]*op.Optional{op.Of("b"), op.Of("a"), op.Of("c")}, op.SkipEmpty)
+	if v := o.Get().(string); v != "a" {
+		t.Errorf("Expected `%v`, got `%v`", "a", v)
+	}
+}
+
+func MinCmpr_test(t *testing.T) {
+	defer shouldNotPanic("optional.Min", t)
+
+	opts := []*op.Optional{op.Of(&S{2}), op.Empty(), op.Of(&S{1}), op.Of(&S{3})}
+	if v := op.Min(opts, op.SkipEmpty).Get().(*S).v; v != 1 {
+		t.Errorf("Expected `%v`, got `%v`", 1, v)
+	}
+	if v := op.Max(opts, op.SkipEmpty).Get().(*S).v; v != 3 {
+		t.Errorf("Expected `%v`, got `%v`", 3, v)
+	}
+}
+
+func MinNoOrdering_test(t *testing.T) {
+	defer shouldPanic("optional.Min without ordering", t)
+
+	op.Min([]*op.Optional{op.Of([]int{1}), op.Of([]int{2})}, op.SkipEmpty)
+	t.Fatal("this code should not be reachable.")
+}
+
+func MinMaxOptions_test(t *testing.T) {
+	defer shouldNotPanic("optional.MinOptions", t)
+
+	opts := []op.Option[float64]{op.OptionOf(2.5), op.OptionEmpty[float64](), op.OptionOf(-1.0)}
+	if v := op.MinOptions(opts, op.SkipEmpty).Get(); v != -1.0 {
+		t.Errorf("Expected `%v`, got `%v`", -1.0, v)
+	}
+	if v := op.MaxOptions(opts, op.SkipEmpty).Get(); v != 2.5 {
+		t.Errorf("Expected `%v`, got `%v`", 2.5, v)
+	}
+	if op.MaxOptions[float64](nil, op.SkipEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func Test_Average(t *testing.T) {
+	t.Run("Average", Average_test)
+	t.Run("Average propagate empty", AveragePropagateEmpty_test)
+	t.Run("AverageOptions", AverageOptions_test)
+}
+
+func Average_test(t *testing.T) {
+	defer shouldNotPanic("optional.Average", t)
+
+	if v := op.Average(column(), op.SkipEmpty).Get().(float64); v != 2 {
+		t.Errorf("Expected `%v`, got `%v`", 2, v)
+	}
+	if op.Average(nil, op.SkipEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func AveragePropagateEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.Average", t)
+
+	if op.Average(column(), op.PropagateEmpty).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func AverageOptions_test(t *testing.T) {
+	defer shouldNotPanic("optional.AverageOptions", t)
+
+	opts := []op.Option[int]{op.OptionOf(1), op.OptionOf(2)}
+	if v := op.AverageOptions(opts, op.PropagateEmpty).Get(); v != 1.5 {
+		t.Errorf("Expected `%v`, got `%v`", 1.5, v)
+	}
+}
@@ -0,0 +1,69 @@
+package optional
+
+// If a value is present, returns the Optional, otherwise returns an Optional
+// describing the value of other, if present. A nil other is treated as empty.
+func (o *Optional) OrOptional(other *Optional) *Optional {
+	if o.present {
+		return o.record("OrOptional")
+	}
+	return o.take(other).record("OrOptional")
+}
+
+// If a value is present, returns the Optional, otherwise returns an Optional
+// describing the value of the Optional produced by the supplying function, if
+// present.
+func (o *Optional) OrElseOptional(f func() *Optional) *Optional {
+	if o.present {
+		return o.record("OrElseOptional")
+	}
+	return o.take(f()).record("OrElseOptional")
+}
+
+// If a value is present in exactly one of the Optional and other, returns an
+// Optional describing that value, otherwise returns an empty Optional.
+func (o *Optional) Xor(other *Optional) *Optional {
+	switch {
+	case o.present && !isPresent(other):
+		return o.record("Xor")
+	case !o.present && isPresent(other):
+		return o.take(other).record("Xor")
+	}
+	return o.set(nil, false).record("Xor")
+}
+
+// If a value is present, returns an Optional describing the value of other,
+// if present, otherwise returns an empty Optional.
+func (o *Optional) And(other *Optional) *Optional {
+	if o.present {
+		return o.take(other).record("And")
+	}
+	return o.set(nil, false).record("And")
+}
+
+// Returns the first present Optional, or an empty Optional if none of the
+// given optionals is present. Nil optionals are skipped.
+func Coalesce(opts ...*Optional) *Optional {
+	for _, o := range opts {
+		if isPresent(o) {
+			return o
+		}
+	}
+	return Empty()
+}
+
+// Sets the Optional to the value of other, if present, otherwise empties it,
+// keeping the reason other is empty.
+func (o *Optional) take(other *Optional) *Optional {
+	if isPresent(other) {
+		return o.set(other.t, true)
+	}
+	o.set(nil, false)
+	if other != nil && other.reason != "" {
+		o.reason = other.reason
+	}
+	return o
+}
+
+func isPresent(o *Optional) bool {
+	return o != nil && o.present
+}
@@ -0,0 +1,157 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"testing"
+)
+
+func Test_OrOptional(t *testing.T) {
+	t.Run("OrOptional", OrOptional_test)
+	t.Run("OrOptional other", OrOptionalOther_test)
+	t.Run("OrOptional nil", OrOptionalNil_test)
+	t.Run("OrElseOptional", OrElseOptional_test)
+	t.Run("OrElseOptional other", OrElseOptionalOther_test)
+}
+
+func OrOptional_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrOptional", t)
+
+	o := op.Of(TEST_INT).OrOptional(op.Of(TEST_OTHER))
+	if v := o.Get().(int); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func OrOptionalOther_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrOptional", t)
+
+	o := op.Empty().OrOptional(op.Of(TEST_OTHER))
+	if v := o.Get().(int); v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+}
+
+func OrOptionalNil_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrOptional", t)
+
+	if op.Empty().OrOptional(nil).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func OrElseOptional_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrElseOptional", t)
+
+	o := op.Of(TEST_INT).OrElseOptional(func() *op.Optional {
+		t.Error("Supplier should not be called.")
+		return nil
+	})
+	if v := o.Get().(int); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func OrElseOptionalOther_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrElseOptional", t)
+
+	o := op.Empty().OrElseOptional(func() *op.Optional {
+		return op.Of(TEST_STR)
+	})
+	if v := o.Get().(string); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+}
+
+func Test_Xor(t *testing.T) {
+	t.Run("Xor receiver", XorReceiver_test)
+	t.Run("Xor other", XorOther_test)
+	t.Run("Xor both", XorBoth_test)
+	t.Run("Xor neither", XorNeither_test)
+}
+
+func XorReceiver_test(t *testing.T) {
+	defer shouldNotPanic("optional.Xor", t)
+
+	o := op.Of(TEST_INT).Xor(op.Empty())
+	if v := o.Get().(int); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func XorOther_test(t *testing.T) {
+	defer shouldNotPanic("optional.Xor", t)
+
+	o := op.Empty().Xor(op.Of(TEST_OTHER))
+	if v := o.Get().(int); v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+}
+
+func XorBoth_test(t *testing.T) {
+	defer shouldNotPanic("optional.Xor", t)
+
+	if op.Of(TEST_INT).Xor(op.Of(TEST_OTHER)).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func XorNeither_test(t *testing.T) {
+	defer shouldNotPanic("optional.Xor", t)
+
+	if op.Empty().Xor(nil).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func Test_And(t *testing.T) {
+	t.Run("And", And_test)
+	t.Run("And empty receiver", AndEmpty_test)
+	t.Run("And empty other", AndEmptyOther_test)
+}
+
+func And_test(t *testing.T) {
+	defer shouldNotPanic("optional.And", t)
+
+	o := op.Of(TEST_INT).And(op.Of(TEST_STR))
+	if v := o.Get().(string); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+}
+
+func AndEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.And", t)
+
+	if op.Empty().And(op.Of(TEST_STR)).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func AndEmptyOther_test(t *testing.T) {
+	defer shouldNotPanic("optional.And", t)
+
+	if op.Of(TEST_INT).And(op.Empty()).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func Test_Coalesce(t *testing.T) {
+	t.Run("Coalesce", Coalesce_test)
+	t.Run("Coalesce none present", CoalesceNone_test)
+}
+
+func Coalesce_test(t *testing.T) {
+	defer shouldNotPanic("optional.Coalesce", t)
+
+	want := op.Of(TEST_STR)
+	if o := op.Coalesce(nil, op.Empty(), want, op.Of(TEST_INT)); o != want {
+		t.Errorf("Expected `%v`, got `%v`", want.Get(), o.Get())
+	}
+}
+
+func CoalesceNone_test(t *testing.T) {
+	defer shouldNotPanic("optional.Coalesce", t)
+
+	if op.Coalesce().IsPresent() || op.Coalesce(nil, op.Empty()).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
@@ -0,0 +1,174 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"testing"
+)
+
+var (
+	sinkInt    int
+	sinkBool   bool
+	sinkString string
+)
+
+func Test_OptionAllocs(t *testing.T) {
+	t.Run("Option int chain", OptionAllocsInt_test)
+	t.Run("Option string chain", OptionAllocsString_test)
+	t.Run("Option pointer chain", OptionAllocsPointer_test)
+	t.Run("Value pointer chain", ValueAllocsPointer_test)
+}
+
+func expectNoAllocs(t *testing.T, f func()) {
+	if n := testing.AllocsPerRun(100, f); n != 0 {
+		t.Errorf("Expected no allocations, got %v", n)
+	}
+}
+
+func OptionAllocsInt_test(t *testing.T) {
+	expectNoAllocs(t, func() {
+		o := op.Map(op.OptionOf(TEST_INT), func(v int) int {
+			return v * 1000
+		}).Filter(func(v int) bool {
+			return v > 0
+		})
+		sinkInt = o.OrElse(TEST_OTHER)
+		sinkInt += op.OptionEmpty[int]().Or(func() int {
+			return TEST_OTHER
+		}).Get()
+		sinkBool = op.OptionOfNilable(sinkInt).IsPresent()
+	})
+}
+
+func OptionAllocsString_test(t *testing.T) {
+	expectNoAllocs(t, func() {
+		o := op.Map(op.OptionOf(TEST_STR), func(v string) string {
+			return v[1:]
+		}).Filter(func(v string) bool {
+			return v != ""
+		})
+		o.IfPresent(func(v string) { sinkString = v })
+	})
+}
+
+func OptionAllocsPointer_test(t *testing.T) {
+	s := &S{TEST_INT}
+	expectNoAllocs(t, func() {
+		sinkInt = op.Map(op.OptionOfNilable(s), func(v *S) *S {
+			return v
+		}).OrElse(&S{}).v
+	})
+}
+
+func ValueAllocsPointer_test(t *testing.T) {
+	s := &S{TEST_INT}
+	expectNoAllocs(t, func() {
+		sinkInt = op.ValueOfNilable(s).Map(func(t op.T) op.T {
+			return t
+		}).Filter(func(t op.T) bool {
+			return t.(*S).v > 0
+		}).OrElse(&S{}).(*S).v
+		sinkString = op.ValueOf(TEST_STR).OrElse("").(string)
+	})
+}
+
+func BenchmarkOf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkBool = op.Of(i + 1000).IsPresent()
+	}
+}
+
+func BenchmarkOptionOf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkBool = op.OptionOf(i + 1000).IsPresent()
+	}
+}
+
+func BenchmarkValueOf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkBool = op.ValueOf(i + 1000).IsPresent()
+	}
+}
+
+func BenchmarkMapFilterOrElse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkInt = op.Of(i + 1000).Map(func(t op.T) op.T {
+			return t.(int) * 2
+		}).Filter(func(t op.T) bool {
+			return t.(int)%3 == 0
+		}).OrElse(0).(int)
+	}
+}
+
+func BenchmarkOptionMapFilterOrElse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkInt = op.Map(op.OptionOf(i+1000), func(v int) int {
+			return v * 2
+		}).Filter(func(v int) bool {
+			return v%3 == 0
+		}).OrElse(0)
+	}
+}
+
+func BenchmarkValueMapFilterOrElse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkInt = op.ValueOf(i + 1000).Map(func(t op.T) op.T {
+			return t.(int) * 2
+		}).Filter(func(t op.T) bool {
+			return t.(int)%3 == 0
+		}).OrElse(0).(int)
+	}
+}
+
+func BenchmarkOrElseEmpty(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkString = op.OfNilable(nil).OrElse(TEST_STR).(string)
+	}
+}
+
+func BenchmarkOptionOrElseEmpty(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkString = op.OptionEmpty[string]().OrElse(TEST_STR)
+	}
+}
+
+func BenchmarkValueOrElseEmpty(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkString = op.ValueOfNilable(nil).OrElse(TEST_STR).(string)
+	}
+}
+
+func BenchmarkIfPresent(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		op.Of(TEST_STR).IfPresent(func(t op.T) {
+			sinkString = t.(string)
+		})
+	}
+}
+
+func BenchmarkOptionIfPresent(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		op.OptionOf(TEST_STR).IfPresent(func(v string) {
+			sinkString = v
+		})
+	}
+}
+
+func BenchmarkValueIfPresent(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		op.ValueOf(TEST_STR).IfPresent(func(t op.T) {
+			sinkString = t.(string)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+// The presence byte that starts the binary encoding of an Optional.
+const (
+	binaryEmpty   byte = 0
+	binaryPresent byte = 1
+)
+
+// Registers the dynamic type of t for the binary and gob encodings of
+// Optional.
+//
+// Payloads are encoded as gob interface values, so every type other than the
+// builtin kinds and slices of them must be registered on both the encoding
+// and decoding side, as with gob.Register.
+func RegisterType(t T) {
+	gob.Register(t)
+}
+
+// Implements encoding.BinaryMarshaler.
+//
+// The encoding is a single presence byte, followed by the gob encoding of the
+// value if one is present.
+func (o *Optional) MarshalBinary() ([]byte, error) {
+	if !o.present {
+		return []byte{binaryEmpty}, nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(binaryPresent)
+	if err := gob.NewEncoder(&buf).Encode(&o.t); err != nil {
+		return nil, fmt.Errorf(
+			"optional: encoding %T (types must be registered with RegisterType): %w",
+			o.t, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Implements encoding.BinaryUnmarshaler.
+func (o *Optional) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("optional: no data to decode")
+	}
+	switch data[0] {
+	case binaryEmpty:
+		o.set(nil, false)
+		return nil
+	case binaryPresent:
+		var t T
+		if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&t); err != nil {
+			return fmt.Errorf("optional: decoding value: %w", err)
+		}
+		o.set(t, t != nil)
+		return nil
+	default:
+		return fmt.Errorf("optional: invalid presence byte %#x", data[0])
+	}
+}
+
+// Implements gob.GobEncoder using the binary encoding.
+func (o *Optional) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// Implements gob.GobDecoder using the binary encoding.
+func (o *Optional) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
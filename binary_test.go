@@ -0,0 +1,130 @@
+package optional_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	op "github.com/MercuryThePlanet/optional"
+	"reflect"
+	"testing"
+)
+
+type Point struct {
+	X, Y int
+}
+
+func init() {
+	op.RegisterType(Point{})
+	op.RegisterType(map[string]int{})
+}
+
+func Test_MarshalBinary(t *testing.T) {
+	t.Run("MarshalBinary builtin kinds", MarshalBinaryKinds_test)
+	t.Run("MarshalBinary empty", MarshalBinaryEmpty_test)
+	t.Run("MarshalBinary registered type", MarshalBinaryRegistered_test)
+	t.Run("MarshalBinary unregistered type", MarshalBinaryUnregistered_test)
+	t.Run("UnmarshalBinary invalid", UnmarshalBinaryInvalid_test)
+}
+
+func binaryRoundTrip(t *testing.T, o *op.Optional) *op.Optional {
+	data, err := o.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := op.Of(TEST_OTHER)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func MarshalBinaryKinds_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalBinary", t)
+
+	values := []op.T{
+		true, "str", []byte("bytes"),
+		int(-1), int8(-2), int16(-3), int32(-4), int64(-5),
+		uint(1), uint8(2), uint16(3), uint32(4), uint64(5), uintptr(6),
+		float32(1.5), float64(2.5), complex64(1 + 2i), complex128(3 + 4i),
+		[]int{1, 2, 3}, []string{"a", "b"},
+	}
+	for _, v := range values {
+		if got := binaryRoundTrip(t, op.Of(v)).Get(); !reflect.DeepEqual(got, v) {
+			t.Errorf("Expected `%v` (%T), got `%v` (%T)", v, v, got, got)
+		}
+	}
+}
+
+func MarshalBinaryEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalBinary", t)
+
+	data, err := op.Empty().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte{0}) {
+		t.Errorf("Expected a single zero byte, got `%v`", data)
+	}
+	if binaryRoundTrip(t, op.Empty()).IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+func MarshalBinaryRegistered_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalBinary", t)
+
+	for _, v := range []op.T{Point{1, 2}, map[string]int{"a": 1}} {
+		if got := binaryRoundTrip(t, op.Of(v)).Get(); !reflect.DeepEqual(got, v) {
+			t.Errorf("Expected `%v`, got `%v`", v, got)
+		}
+	}
+}
+
+func MarshalBinaryUnregistered_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalBinary", t)
+
+	type unregistered struct{ V int }
+	if _, err := op.Of(unregistered{}).MarshalBinary(); err == nil {
+		t.Error("Expected an error for an unregistered type.")
+	}
+}
+
+func UnmarshalBinaryInvalid_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalBinary", t)
+
+	for _, data := range [][]byte{nil, {2}, {1, 0xff}} {
+		if err := op.Empty().UnmarshalBinary(data); err == nil {
+			t.Errorf("Expected an error decoding `%v`.", data)
+		}
+	}
+}
+
+type DTO struct {
+	Name  string
+	Age   *op.Optional
+	Email *op.Optional
+	Home  op.Optional
+}
+
+func Test_Gob(t *testing.T) {
+	t.Run("Gob struct field", GobStruct_test)
+}
+
+func GobStruct_test(t *testing.T) {
+	defer shouldNotPanic("optional.GobEncode", t)
+
+	in := DTO{Name: "a", Age: op.Of(TEST_INT), Email: op.Empty(),
+		Home: *op.Of(Point{3, 4})}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out DTO
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "a" || out.Age.Get() != TEST_INT ||
+		out.Email.IsPresent() || out.Home.Get() != (Point{3, 4}) {
+		t.Errorf("Expected `%v`, got `%v`", in, out)
+	}
+}
@@ -0,0 +1,129 @@
+package optional
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Returns an Option describing the next value received from ch, or an empty
+// Option if ch is closed or no value arrives within d.
+func RecvTimeout[V any](ch <-chan V, d time.Duration) Option[V] {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case v, ok := <-ch:
+		return received(v, ok)
+	case <-t.C:
+		return Option[V]{}
+	}
+}
+
+// Returns an Option describing the next value received from ch, or an empty
+// Option if ch is closed or ctx is done first.
+func RecvCtx[V any](ctx context.Context, ch <-chan V) Option[V] {
+	select {
+	case v, ok := <-ch:
+		return received(v, ok)
+	case <-ctx.Done():
+		return Option[V]{}
+	}
+}
+
+func received[V any](v V, ok bool) Option[V] {
+	if !ok {
+		return Option[V]{}
+	}
+	return OptionOfNilable(v)
+}
+
+// If a value is present, sends it on ch and returns true, otherwise returns
+// false without blocking.
+func SendIfPresent[V any](ch chan<- V, o Option[V]) bool {
+	if !o.present {
+		return false
+	}
+	ch <- o.v
+	return true
+}
+
+// Returns a channel of the optionals received from in, mapped as by Map by the
+// given number of workers. Nil optionals are treated as empty.
+//
+// With more than one worker, optionals may be sent out of order. The returned
+// channel is closed once in is closed and every optional has been sent, or
+// once ctx is done. The stage blocks until its output is received, so a
+// consumer that stops receiving should cancel ctx to release the workers.
+func MapChan(ctx context.Context, in <-chan *Optional, f Mapper, workers int) <-chan *Optional {
+	return stage(ctx, in, workers, func(o *Optional) (*Optional, bool) {
+		return o.Map(f), true
+	})
+}
+
+// Returns a channel of the optionals received from in, filtered as by Filter
+// by the given number of workers. Ordering, closing and cancellation are as
+// for MapChan.
+func FilterChan(ctx context.Context, in <-chan *Optional, f Predicate, workers int) <-chan *Optional {
+	return stage(ctx, in, workers, func(o *Optional) (*Optional, bool) {
+		if !o.present {
+			return o, true
+		}
+		return o.Filter(f), true
+	})
+}
+
+// Returns a channel of the present optionals received from in, dropping
+// empty ones, forwarded by the given number of workers. Ordering, closing and
+// cancellation are as for MapChan.
+func CompactChan(ctx context.Context, in <-chan *Optional, workers int) <-chan *Optional {
+	return stage(ctx, in, workers, func(o *Optional) (*Optional, bool) {
+		return o, o.present
+	})
+}
+
+// Runs f on the optionals received from in with the given number of workers,
+// at least one, sending those it keeps on the returned channel until in is
+// closed or ctx is done.
+func stage(ctx context.Context, in <-chan *Optional, workers int,
+	f func(*Optional) (*Optional, bool)) <-chan *Optional {
+	if workers < 1 {
+		workers = 1
+	}
+	out := make(chan *Optional)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var o *Optional
+				select {
+				case v, ok := <-in:
+					if !ok {
+						return
+					}
+					o = v
+				case <-ctx.Done():
+					return
+				}
+				if o == nil {
+					o = Empty()
+				}
+				o, keep := f(o)
+				if !keep {
+					continue
+				}
+				select {
+				case out <- o:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
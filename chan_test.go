@@ -0,0 +1,261 @@
+package optional_test
+
+import (
+	"context"
+	op "github.com/MercuryThePlanet/optional"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Recv(t *testing.T) {
+	t.Run("RecvTimeout", RecvTimeout_test)
+	t.Run("RecvTimeout timeout", RecvTimeoutExpired_test)
+	t.Run("RecvTimeout closed", RecvTimeoutClosed_test)
+	t.Run("RecvCtx", RecvCtx_test)
+	t.Run("RecvCtx cancelled", RecvCtxCancelled_test)
+	t.Run("SendIfPresent", SendIfPresent_test)
+}
+
+func RecvTimeout_test(t *testing.T) {
+	defer shouldNotPanic("optional.RecvTimeout", t)
+
+	ch := make(chan int, 1)
+	ch <- TEST_INT
+	if v := op.RecvTimeout(ch, time.Second).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func RecvTimeoutExpired_test(t *testing.T) {
+	defer shouldNotPanic("optional.RecvTimeout", t)
+
+	if op.RecvTimeout(make(chan int), time.Millisecond).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func RecvTimeoutClosed_test(t *testing.T) {
+	defer shouldNotPanic("optional.RecvTimeout", t)
+
+	ch := make(chan *S)
+	close(ch)
+	if op.RecvTimeout(ch, time.Second).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func RecvCtx_test(t *testing.T) {
+	defer shouldNotPanic("optional.RecvCtx", t)
+
+	ch := make(chan string, 1)
+	ch <- TEST_STR
+	if v := op.RecvCtx(context.Background(), ch).Get(); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+}
+
+func RecvCtxCancelled_test(t *testing.T) {
+	defer shouldNotPanic("optional.RecvCtx", t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if op.RecvCtx(ctx, make(chan string)).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func SendIfPresent_test(t *testing.T) {
+	defer shouldNotPanic("optional.SendIfPresent", t)
+
+	ch := make(chan int, 1)
+	if op.SendIfPresent(ch, op.OptionEmpty[int]()) || len(ch) != 0 {
+		t.Error("Empty option should not be sent.")
+	}
+	if !op.SendIfPresent(ch, op.OptionOf(TEST_INT)) || <-ch != TEST_INT {
+		t.Error("Present option should be sent.")
+	}
+}
+
+// Sends the optionals on a new channel, closing it after the last one.
+func source(opts ...*op.Optional) <-chan *op.Optional {
+	ch := make(chan *op.Optional)
+	go func() {
+		defer close(ch)
+		for _, o := range opts {
+			ch <- o
+		}
+	}()
+	return ch
+}
+
+// Receives every optional from ch, returning the values of present ones and
+// the number of empty ones.
+func drain(ch <-chan *op.Optional) (values []int, empty int) {
+	for o := range ch {
+		if o.IsPresent() {
+			values = append(values, o.Get().(int))
+		} else {
+			empty++
+		}
+	}
+	return values, empty
+}
+
+func Test_Chan(t *testing.T) {
+	t.Run("MapChan", MapChan_test)
+	t.Run("MapChan workers", MapChanWorkers_test)
+	t.Run("FilterChan", FilterChan_test)
+	t.Run("CompactChan", CompactChan_test)
+	t.Run("Pipeline", ChanPipeline_test)
+	t.Run("Abandoned consumer", ChanAbandoned_test)
+}
+
+func MapChan_test(t *testing.T) {
+	defer shouldNotPanic("optional.MapChan", t)
+
+	out := op.MapChan(context.Background(), source(op.Of(1), op.Empty(), nil, op.Of(2)), func(t op.T) op.T {
+		return t.(int) * 10
+	}, 1)
+	values, empty := drain(out)
+	if len(values) != 2 || values[0] != 10 || values[1] != 20 || empty != 2 {
+		t.Errorf("Expected `[10 20]` and 2 empty, got `%v` and %d", values, empty)
+	}
+}
+
+func MapChanWorkers_test(t *testing.T) {
+	defer shouldNotPanic("optional.MapChan", t)
+
+	opts := make([]*op.Optional, 100)
+	for i := range opts {
+		opts[i] = op.Of(i)
+	}
+
+	// The first four calls wait for each other, which only four workers
+	// mapping at once can get past.
+	var calls int32
+	var arrived sync.WaitGroup
+	arrived.Add(4)
+	release := make(chan struct{})
+	out := op.MapChan(context.Background(), source(opts...), func(t op.T) op.T {
+		if atomic.AddInt32(&calls, 1) <= 4 {
+			arrived.Done()
+			<-release
+		}
+		return t.(int) + 1
+	}, 4)
+
+	done := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		close(release)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected four workers to map at once.")
+	}
+
+	values, _ := drain(out)
+	sort.Ints(values)
+	if len(values) != 100 || values[0] != 1 || values[99] != 100 {
+		t.Errorf("Expected values 1 to 100, got `%v`", values)
+	}
+}
+
+func FilterChan_test(t *testing.T) {
+	defer shouldNotPanic("optional.FilterChan", t)
+
+	out := op.FilterChan(context.Background(), source(op.Of(1), op.Of(2), op.Empty(), op.Of(3)), func(t op.T) bool {
+		return t.(int)%2 == 1
+	}, 0)
+	values, empty := drain(out)
+	if len(values) != 2 || values[0] != 1 || values[1] != 3 || empty != 2 {
+		t.Errorf("Expected `[1 3]` and 2 empty, got `%v` and %d", values, empty)
+	}
+}
+
+func CompactChan_test(t *testing.T) {
+	defer shouldNotPanic("optional.CompactChan", t)
+
+	values, empty := drain(op.CompactChan(context.Background(), source(op.Empty(), op.Of(1), nil, op.Of(2)), 2))
+	sort.Ints(values)
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 || empty != 0 {
+		t.Errorf("Expected `[1 2]` and no empty, got `%v` and %d", values, empty)
+	}
+}
+
+func ChanPipeline_test(t *testing.T) {
+	defer shouldNotPanic("optional.CompactChan", t)
+
+	ctx := context.Background()
+	parsed := op.MapChan(ctx, source(op.Of("1"), op.Of("x"), op.Of("3")), func(t op.T) op.T {
+		if i := op.OfString(t.(string)).ParseInt(); i.IsPresent() {
+			return i.Get()
+		}
+		return nil
+	}, 2)
+	ints := op.MapChan(ctx, op.CompactChan(ctx, parsed, 1), func(t op.T) op.T {
+		return t.(int) * 10
+	}, 1)
+	results := make(chan int, 3)
+	for {
+		o := op.RecvTimeout(ints, time.Second)
+		if !o.IsPresent() {
+			break
+		}
+		op.SendIfPresent(results, op.OptionFrom[int](o.Get()))
+	}
+	close(results)
+	var values []int
+	for v := range results {
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	if len(values) != 2 || values[0] != 10 || values[1] != 30 {
+		t.Errorf("Expected `[10 30]`, got `%v`", values)
+	}
+}
+
+func ChanAbandoned_test(t *testing.T) {
+	defer shouldNotPanic("optional.MapChan", t)
+
+	// An endless source whose consumer stops after the first optional.
+	in := make(chan *op.Optional)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case in <- op.Of(TEST_INT):
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := op.CompactChan(ctx, op.FilterChan(ctx, op.MapChan(ctx, in, func(t op.T) op.T {
+		return t
+	}, 2), func(op.T) bool { return true }, 2), 2)
+	if o := <-out; o.Get() != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, o.Get())
+	}
+	cancel()
+
+	// Every stage stops once ctx is done, closing out.
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Expected the stages to stop after cancellation.")
+		}
+	}
+}
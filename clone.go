@@ -0,0 +1,141 @@
+package optional
+
+import "reflect"
+
+// Returns a new Optional with the same value, reason, trace and decoding
+// type. The value itself is shared, see DeepClone.
+func (o *Optional) Clone() *Optional {
+	c := *o
+	c.trace = append([]Step(nil), o.trace...)
+	return &c
+}
+
+// Returns a new Optional, as Clone does, holding a deep copy of the value.
+//
+// A value with a Clone method taking no arguments and returning a value of
+// its own type is copied by calling it. Otherwise pointers, structs, arrays,
+// slices, maps and interfaces are copied recursively, preserving shared and
+// cyclic references. Unexported struct fields, funcs and channels are copied
+// shallowly; types holding state in unexported fields should implement Clone.
+func (o *Optional) DeepClone() *Optional {
+	c := o.Clone()
+	if c.present {
+		c.t = deepCopy(reflect.ValueOf(c.t), map[reference]reflect.Value{}).Interface()
+	}
+	return c
+}
+
+// Returns a copy of the Option holding a deep copy of the value, as
+// Optional.DeepClone does.
+func (o Option[V]) DeepClone() Option[V] {
+	if o.present {
+		v := deepCopy(reflect.ValueOf(&o.v).Elem(), map[reference]reflect.Value{})
+		o.v = v.Interface().(V)
+	}
+	return o
+}
+
+// Identifies a value referenced by a pointer, slice or map, so that references
+// to it share a single copy.
+type reference struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+func deepCopy(v reflect.Value, seen map[reference]reflect.Value) reflect.Value {
+	if v.Type() == reflect.TypeOf((*Optional)(nil)) && !v.IsNil() && v.CanInterface() {
+		return reflect.ValueOf(v.Interface().(*Optional).DeepClone())
+	}
+	if c, ok := cloneMethod(v); ok {
+		return c
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		ref := reference{v.Type(), v.Pointer()}
+		if c, ok := seen[ref]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[ref] = c
+		c.Elem().Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		ref := reference{v.Type(), v.Pointer()}
+		if c, ok := seen[ref]; ok && c.Len() == v.Len() {
+			return c
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		seen[ref] = c
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		ref := reference{v.Type(), v.Pointer()}
+		if c, ok := seen[ref]; ok {
+			return c
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		seen[ref] = c
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(deepCopy(iter.Key(), seen), deepCopy(iter.Value(), seen))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return c
+	}
+	return v
+}
+
+// Calls the Clone method of v, if it has one returning a value of v's type.
+func cloneMethod(v reflect.Value) (reflect.Value, bool) {
+	if !v.IsValid() || !v.CanInterface() || v.Kind() == reflect.Interface {
+		return v, false
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return v, false
+	}
+	m := v.MethodByName("Clone")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return v, false
+	}
+	c := m.Call(nil)[0]
+	if c.Kind() == reflect.Interface {
+		c = c.Elem()
+	}
+	if !c.IsValid() || c.Type() != v.Type() {
+		return v, false
+	}
+	return c, true
+}
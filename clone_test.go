@@ -0,0 +1,159 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"reflect"
+	"testing"
+)
+
+type node struct {
+	Name   string
+	Next   *node
+	Tags   []string
+	Counts map[string]int
+	Any    interface{}
+	secret []int
+}
+
+// cloned copies itself with a marker, to tell a Clone call from a reflective
+// copy.
+type cloned struct {
+	N      []int
+	Cloned bool
+}
+
+func (c cloned) Clone() cloned {
+	return cloned{append([]int(nil), c.N...), true}
+}
+
+func Test_Clone(t *testing.T) {
+	t.Run("Clone", Clone_test)
+	t.Run("Clone keeps reason and trace", CloneState_test)
+	t.Run("DeepClone", DeepClone_test)
+	t.Run("DeepClone cycle", DeepCloneCycle_test)
+	t.Run("DeepClone Clone method", DeepCloneMethod_test)
+	t.Run("DeepClone nested Optional", DeepCloneNested_test)
+	t.Run("Option DeepClone", OptionDeepClone_test)
+}
+
+func Clone_test(t *testing.T) {
+	defer shouldNotPanic("optional.Clone", t)
+
+	tags := []string{"a"}
+	o := op.Of(tags)
+	c := o.Clone()
+	if c == o {
+		t.Fatal("Clone should return a new Optional.")
+	}
+	c.Map(func(op.T) op.T { return TEST_STR })
+	if !reflect.DeepEqual(o.Get(), tags) {
+		t.Error("Mapping the clone should not change the original.")
+	}
+	tags[0] = "b"
+	if o.Get().([]string)[0] != "b" {
+		t.Error("Clone should share the value.")
+	}
+	if op.Empty().Clone().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func CloneState_test(t *testing.T) {
+	defer shouldNotPanic("optional.Clone", t)
+
+	o := op.Of(TEST_INT).Traced().Filter(func(op.T) bool { return false })
+	c := o.Clone()
+	if len(c.Trace()) != len(o.Trace()) {
+		t.Errorf("Expected trace `%v`, got `%v`", o.Trace(), c.Trace())
+	}
+	c.Or(func(op.Ts) op.T { return TEST_OTHER })
+	if len(c.Trace()) == len(o.Trace()) {
+		t.Error("Clones should not share a trace.")
+	}
+	if op.EmptyBecause("missing").Clone().Reason() != "missing" {
+		t.Error("Clone should keep the reason.")
+	}
+}
+
+func DeepClone_test(t *testing.T) {
+	defer shouldNotPanic("optional.DeepClone", t)
+
+	n := &node{
+		Name:   "a",
+		Next:   &node{Name: "b"},
+		Tags:   []string{"x"},
+		Counts: map[string]int{"x": 1},
+		Any:    []int{1},
+		secret: []int{1},
+	}
+	c := op.Of(n).DeepClone().Get().(*node)
+	if !reflect.DeepEqual(c, n) {
+		t.Fatalf("Expected `%+v`, got `%+v`", n, c)
+	}
+	if c == n || c.Next == n.Next {
+		t.Error("Pointers should be copied.")
+	}
+	c.Tags[0], c.Counts["x"], c.Any.([]int)[0] = "y", 2, 2
+	if n.Tags[0] != "x" || n.Counts["x"] != 1 || n.Any.([]int)[0] != 1 {
+		t.Errorf("Changing the clone should not change the original, got `%+v`", n)
+	}
+	c.secret[0] = 2
+	if n.secret[0] != 2 {
+		t.Error("Unexported fields should be shared.")
+	}
+}
+
+func DeepCloneCycle_test(t *testing.T) {
+	defer shouldNotPanic("optional.DeepClone", t)
+
+	n := &node{Name: "a"}
+	n.Next = &node{Name: "b", Next: n}
+	c := op.Of(n).DeepClone().Get().(*node)
+	if c == n || c.Next.Next != c {
+		t.Error("Cycles should be preserved in the copy.")
+	}
+}
+
+func DeepCloneMethod_test(t *testing.T) {
+	defer shouldNotPanic("optional.DeepClone", t)
+
+	v := []cloned{{N: []int{1}}}
+	c := op.Of(v).DeepClone().Get().([]cloned)
+	if !c[0].Cloned {
+		t.Error("Clone method should be called.")
+	}
+	c[0].N[0] = 2
+	if v[0].N[0] != 1 {
+		t.Error("Changing the clone should not change the original.")
+	}
+}
+
+func DeepCloneNested_test(t *testing.T) {
+	defer shouldNotPanic("optional.DeepClone", t)
+
+	inner := []int{1}
+	c := op.Of(op.Of(inner)).DeepClone().Get().(*op.Optional)
+	c.Get().([]int)[0] = 2
+	if inner[0] != 1 {
+		t.Error("Nested optionals should be deep cloned.")
+	}
+}
+
+func OptionDeepClone_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option.DeepClone", t)
+
+	m := map[string][]int{"a": {1}}
+	c := op.OptionOf(m).DeepClone()
+	c.Get()["a"][0] = 2
+	if m["a"][0] != 1 {
+		t.Error("Changing the clone should not change the original.")
+	}
+	if op.OptionEmpty[map[string]int]().DeepClone().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	a := op.OptionOf[interface{}]([]int{1})
+	a.DeepClone().Get().([]int)[0] = 2
+	if a.Get().([]int)[0] != 1 {
+		t.Error("Changing the clone should not change the original.")
+	}
+}
@@ -0,0 +1,178 @@
+// Command optgen generates typed optional wrappers.
+//
+// Given a type name, optgen writes a file declaring OptionalFoo with the
+// method set of optional.Optional typed to Foo, plus encoding/json and
+// database/sql support. The generated code does not use type parameters, so it
+// works in modules pinned below Go 1.18, and it may be extended with domain
+// methods in another file of the same package.
+//
+// It is intended to be run from go:generate:
+//
+//	//go:generate optgen -type Foo
+//	//go:generate optgen -type *Bar -name OptionalBar -tests
+//	//go:generate optgen -type time.Time -import time
+//
+// Flags:
+//
+//	-type     the type to wrap, e.g. Foo, *Foo or time.Time (required)
+//	-name     the name of the generated type (default "Optional" + type name)
+//	-package  the package of the generated file (default $GOPACKAGE)
+//	-import   a package to import for a qualified type, may be repeated
+//	-output   the output file (default <type>_optional.go)
+//	-tests    also write a _test.go file exercising the generated type
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Config describes a single generated type.
+type Config struct {
+	Package string
+	Type    string
+	Name    string
+	Imports []string
+}
+
+// Suffix returns the suffix of the generated constructors, e.g. Foo for
+// EmptyFoo and OfFoo.
+func (c Config) Suffix() string {
+	if s := strings.TrimPrefix(c.Name, "Optional"); s != "" &&
+		token.IsIdentifier(s) {
+		return s
+	}
+	return c.Name
+}
+
+// Pointer reports whether the wrapped type is a pointer, in which case a nil
+// value is treated as empty.
+func (c Config) Pointer() bool {
+	return strings.HasPrefix(c.Type, "*")
+}
+
+// Returns the default name of the generated type.
+func defaultName(typ string) string {
+	base := strings.TrimPrefix(typ, "*")
+	if i := strings.LastIndex(base, "."); i >= 0 {
+		base = base[i+1:]
+	}
+	r := []rune(base)
+	r[0] = unicode.ToUpper(r[0])
+	name := "Optional" + string(r)
+	if strings.HasPrefix(typ, "*") {
+		name += "Ptr"
+	}
+	return name
+}
+
+func (c Config) validate() error {
+	if c.Package == "" || !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("invalid package name %q, set -package", c.Package)
+	}
+	base := strings.TrimPrefix(c.Type, "*")
+	parts := strings.Split(base, ".")
+	if base == "" || len(parts) > 2 {
+		return fmt.Errorf("invalid type %q", c.Type)
+	}
+	for _, p := range parts {
+		if !token.IsIdentifier(p) {
+			return fmt.Errorf("invalid type %q", c.Type)
+		}
+	}
+	if !token.IsIdentifier(c.Name) || !token.IsExported(c.Name) {
+		return fmt.Errorf("invalid type name %q", c.Name)
+	}
+	return nil
+}
+
+// Generate returns the formatted source of the generated type, and of its
+// tests if tests is set.
+func Generate(c Config, tests bool) ([]byte, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	tmpl := sourceTemplate
+	if tests {
+		tmpl = testTemplate
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, c); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+type imports []string
+
+func (i *imports) String() string {
+	return strings.Join(*i, ",")
+}
+
+func (i *imports) Set(s string) error {
+	*i = append(*i, s)
+	return nil
+}
+
+func run(args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("optgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var c Config
+	fs.StringVar(&c.Type, "type", "", "the type to wrap, e.g. Foo, *Foo or time.Time")
+	fs.StringVar(&c.Name, "name", "", "the name of the generated type")
+	fs.StringVar(&c.Package, "package", os.Getenv("GOPACKAGE"),
+		"the package of the generated file")
+	fs.Var((*imports)(&c.Imports), "import", "a package to import, may be repeated")
+	output := fs.String("output", "", "the output file")
+	tests := fs.Bool("tests", false, "also write a _test.go file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if c.Type == "" {
+		return errors.New("-type is required")
+	}
+	if c.Name == "" {
+		c.Name = defaultName(c.Type)
+	}
+	if *output == "" {
+		*output = strings.ToLower(strings.TrimPrefix(c.Name, "Optional")) +
+			"_optional.go"
+	}
+
+	src, err := Generate(c, false)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		return err
+	}
+	if !*tests {
+		return nil
+	}
+	src, err = Generate(c, true)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(*output, ".go")+"_test.go", src, 0o644)
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "optgen:", err)
+		}
+		os.Exit(2)
+	}
+}
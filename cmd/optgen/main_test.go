@@ -0,0 +1,108 @@
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Generate(t *testing.T) {
+	t.Run("Generate", Generate_test)
+	t.Run("Generate invalid config", GenerateInvalid_test)
+	t.Run("Generated code compiles and passes its tests", GenerateCompiles_test)
+}
+
+func Generate_test(t *testing.T) {
+	src, err := Generate(Config{Package: "p", Type: "*Foo", Name: "OptionalFoo"},
+		false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, decl := range []string{
+		"type OptionalFoo struct",
+		"func EmptyFoo() *OptionalFoo",
+		"func OfFoo(v *Foo) *OptionalFoo",
+		"func OfNilableFoo(v *Foo) *OptionalFoo",
+		"func (o *OptionalFoo) Map(f func(*Foo) *Foo) *OptionalFoo",
+		"func (o OptionalFoo) MarshalJSON() ([]byte, error)",
+		"func (o *OptionalFoo) Scan(src interface{}) error",
+	} {
+		if !strings.Contains(string(src), decl) {
+			t.Errorf("Generated code should contain `%v`.", decl)
+		}
+	}
+}
+
+func GenerateInvalid_test(t *testing.T) {
+	for _, c := range []Config{
+		{Package: "", Type: "Foo", Name: "OptionalFoo"},
+		{Package: "p", Type: "a.b.C", Name: "OptionalC"},
+		{Package: "p", Type: "[]int", Name: "OptionalInts"},
+		{Package: "p", Type: "Foo", Name: "optionalFoo"},
+	} {
+		if _, err := Generate(c, false); err == nil {
+			t.Errorf("Expected an error for %+v.", c)
+		}
+	}
+	if err := run([]string{"-package", "p"}, io.Discard); err == nil {
+		t.Error("Expected an error without -type.")
+	}
+}
+
+// Generates wrappers into a module pinned to Go 1.16, which predates type
+// parameters, and runs the generated tests there.
+func GenerateCompiles_test(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test of generated code in short mode")
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/gen\n\ngo 1.16\n",
+		"types.go": `package gen
+
+type Point struct {
+	X, Y int
+}
+
+type Celsius float64
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content),
+			0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	for _, args := range [][]string{
+		{"-type", "int"},
+		{"-type", "string"},
+		{"-type", "Celsius"},
+		{"-type", "Point"},
+		{"-type", "*Point"},
+		{"-type", "time.Time", "-import", "time"},
+	} {
+		args = append(args, "-package", "gen", "-tests")
+		if err := run(args, io.Discard); err != nil {
+			t.Fatalf("optgen %v: %v", args, err)
+		}
+	}
+
+	cmd := exec.Command("go", "test", "./...")
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOTOOLCHAIN=local")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test of generated code failed: %v\n%s", err, out)
+	}
+}
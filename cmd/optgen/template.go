@@ -0,0 +1,372 @@
+package main
+
+import "text/template"
+
+// Elem returns the pointed-to type of a pointer type.
+func (c Config) Elem() string {
+	return c.Type[1:]
+}
+
+var sourceTemplate = template.Must(template.New("source").Parse(`
+{{- define "present"}}{{if .Pointer}}v != nil{{else}}true{{end}}{{end -}}
+// Code generated by optgen -type {{.Type}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// {{.Name}} is an optional container for a {{.Type}} value.
+type {{.Name}} struct {
+	v       {{.Type}}
+	present bool
+}
+
+// Returns an empty {{.Name}} instance.
+func Empty{{.Suffix}}() *{{.Name}} {
+	return &{{.Name}}{}
+}
+
+// Returns an {{.Name}} describing the given {{if .Pointer}}non-nil {{end}}value.
+func Of{{.Suffix}}(v {{.Type}}) *{{.Name}} {
+{{- if .Pointer}}
+	if v == nil {
+		panic("Of{{.Suffix}} takes a non-nil value. Use OfNilable{{.Suffix}} for potentially nil values.")
+	}
+{{- end}}
+	return &{{.Name}}{v: v, present: true}
+}
+{{- if .Pointer}}
+
+// Returns an {{.Name}} describing the given value, if non-nil, otherwise
+// returns an empty {{.Name}}.
+func OfNilable{{.Suffix}}(v {{.Type}}) *{{.Name}} {
+	return &{{.Name}}{v: v, present: v != nil}
+}
+{{- end}}
+
+// If the error is nil, returns an {{.Name}} describing the given value,
+// otherwise returns an empty {{.Name}}.
+func OfErrorable{{.Suffix}}(v {{.Type}}, err error) *{{.Name}} {
+	if err == nil {
+		return &{{.Name}}{v: v, present: {{template "present" .}}}
+	}
+	return &{{.Name}}{}
+}
+
+func (o *{{.Name}}) set(v {{.Type}}, present bool) *{{.Name}} {
+	if !present {
+		var zero {{.Type}}
+		v = zero
+	}
+	o.v = v
+	o.present = present
+	return o
+}
+
+// Indicates if another {{.Name}} is equal to this one.
+//
+// Two optionals are equal if they are both empty, or if their values are
+// deeply equal.
+func (o *{{.Name}}) Equals(other *{{.Name}}) bool {
+	if other == nil || o.present != other.present {
+		return false
+	}
+	return !o.present || reflect.DeepEqual(o.v, other.v)
+}
+
+// If a value is present and matches the given predicate, returns this
+// {{.Name}}, otherwise returns an empty {{.Name}}.
+func (o *{{.Name}}) Filter(f func({{.Type}}) bool) *{{.Name}} {
+	if o.present && f(o.v) {
+		return o
+	}
+	return o.set(o.v, false)
+}
+
+// If a value is present, returns this {{.Name}}, otherwise returns an
+// {{.Name}} describing the value produced by the supplying function.
+func (o *{{.Name}}) Or(f func() {{.Type}}) *{{.Name}} {
+	if o.present {
+		return o
+	}
+	v := f()
+	return o.set(v, {{template "present" .}})
+}
+
+// If a value is present, returns the value, otherwise returns the zero value.
+func (o *{{.Name}}) Get() {{.Type}} {
+	return o.v
+}
+
+// If a value is present, performs the given action with the value, otherwise
+// does nothing.
+func (o *{{.Name}}) IfPresent(f func({{.Type}})) {
+	if o.present {
+		f(o.v)
+	}
+}
+
+// If a value is present, performs the given action with the value, otherwise
+// performs the given runnable action.
+func (o *{{.Name}}) IfPresentOrElse(f func({{.Type}}), other func()) {
+	if o.present {
+		f(o.v)
+	} else {
+		other()
+	}
+}
+
+// If a value is present, returns true, otherwise false.
+func (o *{{.Name}}) IsPresent() bool {
+	return o.present
+}
+
+// If a value is present, returns an {{.Name}} describing the result of
+// applying the given mapping function to the value, otherwise returns an
+// empty {{.Name}}.
+func (o *{{.Name}}) Map(f func({{.Type}}) {{.Type}}) *{{.Name}} {
+	if o.present {
+		v := f(o.v)
+		return o.set(v, {{template "present" .}})
+	}
+	return o
+}
+
+// If a value is present, returns the result of applying the given
+// {{.Name}}-bearing mapping function to the value, otherwise returns an
+// empty {{.Name}}.
+func (o *{{.Name}}) FlatMap(f func({{.Type}}) *{{.Name}}) *{{.Name}} {
+	if o.present {
+		if mapped := f(o.v); mapped != nil {
+			return mapped
+		}
+		return o.set(o.v, false)
+	}
+	return o
+}
+
+// If a value is present, returns the value, otherwise returns other.
+func (o *{{.Name}}) OrElse(other {{.Type}}) {{.Type}} {
+	if o.present {
+		return o.v
+	}
+	return other
+}
+
+// If a value is present, returns the value, otherwise returns the result
+// produced by the supplying function.
+func (o *{{.Name}}) OrElseGet(f func() {{.Type}}) {{.Type}} {
+	if o.present {
+		return o.v
+	}
+	return f()
+}
+
+// If a value is present, returns the value, otherwise panics.
+func (o *{{.Name}}) OrElsePanic(p string) {{.Type}} {
+	if o.present {
+		return o.v
+	}
+	panic(p)
+}
+
+// Implements json.Marshaler. An empty {{.Name}} is marshaled as null.
+func (o {{.Name}}) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.v)
+}
+
+// Implements json.Unmarshaler. A null value empties the {{.Name}}.
+func (o *{{.Name}}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		o.set(o.v, false)
+		return nil
+	}
+	var v {{.Type}}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.set(v, {{template "present" .}})
+	return nil
+}
+
+// Implements driver.Valuer. An empty {{.Name}} is stored as NULL.
+func (o {{.Name}}) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.v)
+}
+
+// Implements sql.Scanner. NULL empties the {{.Name}}.
+func (o *{{.Name}}) Scan(src interface{}) error {
+	if src == nil {
+		o.set(o.v, false)
+		return nil
+	}
+{{- if .Pointer}}
+	v := new({{.Elem}})
+	target := reflect.ValueOf(v).Elem()
+{{- else}}
+	var v {{.Type}}
+	target := reflect.ValueOf(&v).Elem()
+{{- end}}
+	if s, ok := target.Addr().Interface().(sql.Scanner); ok {
+		if err := s.Scan(src); err != nil {
+			return err
+		}
+		o.set(v, true)
+		return nil
+	}
+
+	value := reflect.ValueOf(src)
+	if b, ok := src.([]byte); ok {
+		if target.Kind() == reflect.String {
+			value = reflect.ValueOf(string(b))
+		} else {
+			value = reflect.ValueOf(append([]byte(nil), b...))
+		}
+	}
+	from, to := value.Type(), target.Type()
+	numeric := func(k reflect.Kind) bool {
+		return k >= reflect.Int && k <= reflect.Float64
+	}
+	if !from.ConvertibleTo(to) ||
+		(from.Kind() != to.Kind() && !(numeric(from.Kind()) && numeric(to.Kind()))) {
+		return fmt.Errorf("{{.Name}}: cannot scan %T into {{.Type}}", src)
+	}
+	target.Set(value.Convert(to))
+	if numeric(to.Kind()) && target.Convert(from).Interface() != value.Interface() {
+		return fmt.Errorf("{{.Name}}: %v overflows {{.Type}}", src)
+	}
+	o.set(v, true)
+	return nil
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`
+{{- define "sample"}}
+{{- if .Pointer}}
+	v := new({{.Elem}})
+{{- else}}
+	var v {{.Type}}
+{{- end}}
+{{- end -}}
+// Code generated by optgen -type {{.Type}} -tests; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+func Test{{.Name}}(t *testing.T) {
+	{{- template "sample" .}}
+	var zero {{.Type}}
+
+	if Empty{{.Suffix}}().IsPresent() {
+		t.Error("Empty optional should have no value")
+	}
+	if o := Of{{.Suffix}}(v); !o.IsPresent() || !reflect.DeepEqual(o.Get(), v) {
+		t.Errorf("Expected ` + "`%v`, got `%v`" + `", v, o.Get())
+	}
+	if OfErrorable{{.Suffix}}(v, errors.New("test")).IsPresent() {
+		t.Error("Optional with an error should be empty.")
+	}
+	if Of{{.Suffix}}(v).Filter(func({{.Type}}) bool { return false }).IsPresent() {
+		t.Error("Filtered optional should be empty.")
+	}
+	if !Of{{.Suffix}}(v).Map(func(x {{.Type}}) {{.Type}} { return x }).Equals(Of{{.Suffix}}(v)) {
+		t.Error("Identity Map should not change the value.")
+	}
+	if !Empty{{.Suffix}}().FlatMap(Of{{.Suffix}}).Equals(Empty{{.Suffix}}()) {
+		t.Error("FlatMap on an empty optional should be empty.")
+	}
+	if !Of{{.Suffix}}(v).FlatMap(Of{{.Suffix}}).Equals(Of{{.Suffix}}(v)) {
+		t.Error("FlatMap with the constructor should not change the value.")
+	}
+	if !Empty{{.Suffix}}().Or(func() {{.Type}} { return v }).Equals(Of{{.Suffix}}(v)) {
+		t.Error("Or on an empty optional should use the supplier.")
+	}
+	if r := Empty{{.Suffix}}().OrElse(zero); !reflect.DeepEqual(r, zero) {
+		t.Errorf("Expected ` + "`%v`, got `%v`" + `", zero, r)
+	}
+	if r := Of{{.Suffix}}(v).OrElseGet(func() {{.Type}} { return zero }); !reflect.DeepEqual(r, v) {
+		t.Errorf("Expected ` + "`%v`, got `%v`" + `", v, r)
+	}
+
+	present := false
+	Of{{.Suffix}}(v).IfPresent(func({{.Type}}) { present = true })
+	Empty{{.Suffix}}().IfPresentOrElse(func({{.Type}}) {
+		t.Error("IfPresentOrElse first was reached when it should not have been.")
+	}, func() {})
+	if !present {
+		t.Error("IfPresent was not reached when it should have been.")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("OrElsePanic should panic and did not.")
+		}
+	}()
+	Empty{{.Suffix}}().OrElsePanic("empty")
+}
+
+func Test{{.Name}}JSON(t *testing.T) {
+	{{- template "sample" .}}
+
+	for _, o := range []*{{.Name}}{Of{{.Suffix}}(v), Empty{{.Suffix}}()} {
+		data, err := json.Marshal(o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := Empty{{.Suffix}}()
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equals(o) {
+			t.Errorf("JSON round trip of ` + "`%s`" + ` changed the value", data)
+		}
+	}
+}
+
+func Test{{.Name}}SQL(t *testing.T) {
+	{{- template "sample" .}}
+
+	if dv, err := Empty{{.Suffix}}().Value(); dv != nil || err != nil {
+		t.Errorf("Expected NULL, got ` + "`%v`" + `, %v", dv, err)
+	}
+	o := Of{{.Suffix}}(v)
+	if err := o.Scan(nil); err != nil || o.IsPresent() {
+		t.Errorf("Scanning NULL should empty the optional, got %v", err)
+	}
+
+	dv, err := Of{{.Suffix}}(v).Value()
+	if err != nil {
+		t.Skipf("{{.Type}} is not a driver value: %v", err)
+	}
+	if err := o.Scan(dv); err != nil {
+		t.Fatal(err)
+	}
+	if !o.Equals(Of{{.Suffix}}(v)) {
+		t.Errorf("SQL round trip of ` + "`%v`" + ` changed the value", dv)
+	}
+}
+`))
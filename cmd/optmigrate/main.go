@@ -0,0 +1,710 @@
+// Command optmigrate rewrites uses of the untyped *Optional API to the generic
+// Option API.
+//
+// It type-checks each package and rewrites chains that start with Of,
+// OfNilable or OfErrorable, continue with Map and Filter, and end in Get,
+// OrElse or OrElsePanic followed by a type assertion, or in IsPresent,
+// IfPresent or IfPresentOrElse. Mapper parameters are retyped, the type
+// assertions on them are dropped and mapper results are typed from their
+// return statements:
+//
+//	n := op.OfNilable(a).Map(func(t op.T) op.T {
+//		return t.(*A).n
+//	}).OrElse(0).(int)
+//
+// becomes
+//
+//	n := op.Map(op.OptionOfNilable(a), func(t *A) int {
+//		return t.n
+//	}).OrElse(0)
+//
+// Because Option.Get returns the zero value for an empty Option where a type
+// assertion on Optional.Get panics, Get followed by a type assertion becomes
+// OrElsePanic so that empty chains still panic.
+//
+// Chains whose concrete types cannot be inferred, or whose *Optional escapes
+// the expression, are left alone and reported on standard error. Chains
+// nested inside the mappers of another chain are rewritten by running the
+// tool again. A rewritten file that does not type-check is left unchanged.
+//
+// Usage:
+//
+//	optmigrate [-w] [dir ...]
+//
+// By default optmigrate prints a diff of the changes. With -w it writes them
+// to the source files instead.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const optionalPath = "github.com/MercuryThePlanet/optional"
+
+func main() {
+	w := flag.Bool("w", false, "write changes to the source files")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: optmigrate [-w] [dir ...]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	failed := false
+	for _, dir := range dirs {
+		if err := migrateDir(dir, *w, os.Stdout, os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, "optmigrate:", err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// Migrates the package in dir, and its external test package if any.
+func migrateDir(dir string, write bool, stdout, stderr io.Writer) error {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return err
+	}
+	for _, names := range [][]string{
+		append(append([]string(nil), bp.GoFiles...), bp.TestGoFiles...),
+		bp.XTestGoFiles,
+	} {
+		if len(names) == 0 {
+			continue
+		}
+		paths := make([]string, len(names))
+		for i, name := range names {
+			paths[i] = filepath.Join(dir, name)
+		}
+		results, err := migratePackage(paths, stderr)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			r, ok := results[path]
+			if !ok {
+				continue
+			}
+			if write {
+				if err := os.WriteFile(path, r.after, 0o644); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprint(stdout, unifiedDiff(path, r.before, r.after))
+			}
+		}
+	}
+	return nil
+}
+
+type result struct {
+	before, after []byte
+}
+
+// Returns the rewritten source of every changed file of the package.
+func migratePackage(paths []string, stderr io.Writer) (map[string]result,
+	error) {
+	sources := map[string][]byte{}
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources[path] = src
+	}
+
+	fset, pkg, files, info, err := check(paths, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	results := map[string]result{}
+	for i, file := range files {
+		path := paths[i]
+		m := newMigrator(fset, pkg, info, file, sources[path])
+		if m == nil {
+			continue
+		}
+		after, reports := m.migrate()
+		if after != nil {
+			rewritten := map[string][]byte{}
+			for p, s := range sources {
+				rewritten[p] = s
+			}
+			rewritten[path] = after
+			if _, _, _, _, err := check(paths, rewritten); err != nil {
+				reports = []string{fmt.Sprintf(
+					"%s: left unchanged, the rewrite does not type-check: %v",
+					path, err)}
+				after = nil
+			} else {
+				sources[path] = after
+				results[path] = result{m.src, after}
+			}
+		}
+		for _, r := range reports {
+			fmt.Fprintln(stderr, r)
+		}
+	}
+	return results, nil
+}
+
+// Parses and type-checks the files of a package.
+func check(paths []string, sources map[string][]byte) (*token.FileSet,
+	*types.Package, []*ast.File, *types.Info, error) {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, len(paths))
+	for i, path := range paths {
+		f, err := parser.ParseFile(fset, path, sources[path],
+			parser.ParseComments)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		files[i] = f
+	}
+	info := &types.Info{
+		Types:     map[ast.Expr]types.TypeAndValue{},
+		Defs:      map[*ast.Ident]types.Object{},
+		Uses:      map[*ast.Ident]types.Object{},
+		Implicits: map[ast.Node]types.Object{},
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(files[0].Name.Name, fset, files, info)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return fset, pkg, files, info, nil
+}
+
+// A migrator rewrites the optional chains of a single file.
+type migrator struct {
+	fset *token.FileSet
+	pkg  *types.Package
+	info *types.Info
+	file *ast.File
+	src  []byte
+	// The name the optional package is imported as.
+	op string
+	// The names imported packages are referred to by.
+	imports map[string]string
+}
+
+// Returns a migrator for the file, or nil if the file does not import the
+// optional package.
+func newMigrator(fset *token.FileSet, pkg *types.Package, info *types.Info,
+	file *ast.File, src []byte) *migrator {
+	m := &migrator{fset: fset, pkg: pkg, info: info, file: file, src: src,
+		imports: map[string]string{}}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		var name string
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else if pkg, ok := info.Implicits[spec].(*types.PkgName); ok {
+			name = pkg.Imported().Name()
+		} else {
+			name = path[strings.LastIndex(path, "/")+1:]
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		m.imports[path] = name
+		if path == optionalPath {
+			m.op = name
+		}
+	}
+	if m.op == "" {
+		return nil
+	}
+	return m
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+// Returns the rewritten source, or nil if nothing was rewritten, and the
+// reports for the chains left alone.
+func (m *migrator) migrate() ([]byte, []string) {
+	var edits []edit
+	covered := map[*ast.CallExpr]bool{}
+	failures := map[*ast.CallExpr]string{}
+
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		expr, ok := n.(ast.Expr)
+		if !ok {
+			return true
+		}
+		base, ok := m.terminal(expr)
+		if !ok {
+			return true
+		}
+		text, err := m.rewriteTerminal(expr)
+		if err != nil {
+			failures[base] = err.Error()
+			return true
+		}
+		edits = append(edits, edit{m.offset(expr.Pos()), m.offset(expr.End()),
+			text})
+		covered[base] = true
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && call != base &&
+				m.constructor(call) != "" {
+				failures[call] = "nested in a rewritten chain, run optmigrate again"
+			}
+			return true
+		})
+		return false
+	})
+
+	var reports []string
+	ast.Inspect(m.file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || covered[call] || m.constructor(call) == "" {
+			return true
+		}
+		reason, ok := failures[call]
+		if !ok {
+			reason = "the *Optional is used outside a single chain"
+		}
+		reports = append(reports, fmt.Sprintf("%s: left alone: %s",
+			m.fset.Position(call.Pos()), reason))
+		return true
+	})
+
+	if len(edits) == 0 {
+		return nil, reports
+	}
+	out, err := format.Source(apply(m.src, edits))
+	if err != nil {
+		return nil, []string{fmt.Sprintf("%s: left unchanged: %v",
+			m.fset.Position(m.file.Pos()).Filename, err)}
+	}
+	return out, reports
+}
+
+func apply(src []byte, edits []edit) []byte {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+	var buf bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		buf.Write(src[last:e.start])
+		buf.WriteString(e.text)
+		last = e.end
+	}
+	buf.Write(src[last:])
+	return buf.Bytes()
+}
+
+func (m *migrator) offset(pos token.Pos) int {
+	return m.fset.Position(pos).Offset
+}
+
+func (m *migrator) text(n ast.Node) string {
+	return string(m.src[m.offset(n.Pos()):m.offset(n.End())])
+}
+
+// Reports whether the expression ends a chain that starts with a
+// constructor, and returns the constructor call.
+func (m *migrator) terminal(expr ast.Expr) (*ast.CallExpr, bool) {
+	var call *ast.CallExpr
+	switch e := expr.(type) {
+	case *ast.TypeAssertExpr:
+		c, ok := unparen(e.X).(*ast.CallExpr)
+		if !ok || e.Type == nil {
+			return nil, false
+		}
+		switch m.method(c) {
+		case "Get", "OrElse", "OrElsePanic":
+			call = c
+		default:
+			return nil, false
+		}
+	case *ast.CallExpr:
+		switch m.method(e) {
+		case "IsPresent", "IfPresent", "IfPresentOrElse":
+			call = e
+		default:
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	for {
+		recv, ok := unparen(call.Fun.(*ast.SelectorExpr).X).(*ast.CallExpr)
+		if !ok {
+			return nil, false
+		}
+		if m.constructor(recv) != "" {
+			return recv, true
+		}
+		if m.method(recv) == "" {
+			return nil, false
+		}
+		call = recv
+	}
+}
+
+func (m *migrator) rewriteTerminal(expr ast.Expr) (string, error) {
+	if assert, ok := expr.(*ast.TypeAssertExpr); ok {
+		call := unparen(assert.X).(*ast.CallExpr)
+		recv, v, err := m.chain(call.Fun.(*ast.SelectorExpr).X)
+		if err != nil {
+			return "", err
+		}
+		if !types.Identical(m.info.TypeOf(assert.Type), v) {
+			return "", fmt.Errorf("the value has type %s, not %s",
+				m.typeString(v), m.text(assert.Type))
+		}
+		switch name := m.method(call); name {
+		case "Get":
+			msg := "interface conversion: interface {} is nil, not " +
+				m.text(assert.Type)
+			return recv + ".OrElsePanic(" + strconv.Quote(msg) + ")", nil
+		case "OrElse":
+			other := call.Args[0]
+			if t := m.info.TypeOf(other); !types.AssignableTo(t, v) {
+				return "", fmt.Errorf("the OrElse value has type %s, not %s",
+					m.typeString(t), m.typeString(v))
+			}
+			return recv + ".OrElse(" + m.text(other) + ")", nil
+		default:
+			return recv + "." + name + "(" + m.text(call.Args[0]) + ")", nil
+		}
+	}
+
+	call := expr.(*ast.CallExpr)
+	recv, v, err := m.chain(call.Fun.(*ast.SelectorExpr).X)
+	if err != nil {
+		return "", err
+	}
+	switch name := m.method(call); name {
+	case "IsPresent":
+		return recv + ".IsPresent()", nil
+	case "IfPresent":
+		f, _, err := m.funcLit(call.Args[0], v, nil)
+		if err != nil {
+			return "", err
+		}
+		return recv + ".IfPresent(" + f + ")", nil
+	default:
+		f, _, err := m.funcLit(call.Args[0], v, nil)
+		if err != nil {
+			return "", err
+		}
+		return recv + ".IfPresentOrElse(" + f + ", " + m.text(call.Args[1]) +
+			")", nil
+	}
+}
+
+// Returns the rewritten chain and the type of its value.
+func (m *migrator) chain(expr ast.Expr) (string, types.Type, error) {
+	call := unparen(expr).(*ast.CallExpr)
+	if name := m.constructor(call); name != "" {
+		var v types.Type
+		if tuple, ok := m.info.TypeOf(call.Args[0]).(*types.Tuple); ok {
+			v = tuple.At(0).Type()
+		} else {
+			v = types.Default(m.info.TypeOf(call.Args[0]))
+		}
+		if isEmptyInterface(v) {
+			return "", nil, fmt.Errorf("%s is called with an interface{} value",
+				name)
+		}
+		if basic, ok := v.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+			return "", nil, fmt.Errorf("%s is called with nil", name)
+		}
+		args := make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			args[i] = m.text(arg)
+		}
+		return fmt.Sprintf("%s.Option%s(%s)", m.op, name,
+			strings.Join(args, ", ")), v, nil
+	}
+
+	recv, v, err := m.chain(call.Fun.(*ast.SelectorExpr).X)
+	if err != nil {
+		return "", nil, err
+	}
+	switch name := m.method(call); name {
+	case "Map":
+		f, u, err := m.funcLit(call.Args[0], v, types.NewInterfaceType(nil, nil))
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s.Map(%s, %s)", m.op, recv, f), u, nil
+	case "Filter":
+		f, _, err := m.funcLit(call.Args[0], v, types.Typ[types.Bool])
+		if err != nil {
+			return "", nil, err
+		}
+		return recv + ".Filter(" + f + ")", v, nil
+	default:
+		return "", nil, fmt.Errorf("%s is not supported", name)
+	}
+}
+
+// Rewrites a function literal taking an interface{} parameter to take a
+// parameter of type v, dropping the type assertions on it. If result is an
+// empty interface, the result type is inferred from the return statements
+// and returned.
+func (m *migrator) funcLit(expr ast.Expr, v types.Type,
+	result types.Type) (string, types.Type, error) {
+	lit, ok := unparen(expr).(*ast.FuncLit)
+	if !ok {
+		return "", nil, errors.New("the function is not a function literal")
+	}
+	params := lit.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return "", nil, errors.New("the function does not take one parameter")
+	}
+	base := m.offset(lit.Pos())
+	var edits []edit
+	replace := func(n ast.Node, text string) {
+		edits = append(edits, edit{m.offset(n.Pos()) - base,
+			m.offset(n.End()) - base, text})
+	}
+
+	typ := m.typeString(v)
+	if typ == "" {
+		return "", nil, fmt.Errorf("type %s is not imported", v)
+	}
+	replace(params[0].Type, typ)
+	if len(params[0].Names) == 1 {
+		if err := m.dropAsserts(lit, params[0].Names[0], v, replace); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var u types.Type
+	if result != nil && isEmptyInterface(result) {
+		var err error
+		if u, err = m.resultType(lit); err != nil {
+			return "", nil, err
+		}
+		text := m.typeString(u)
+		if text == "" {
+			return "", nil, fmt.Errorf("type %s is not imported", u)
+		}
+		replace(lit.Type.Results.List[0].Type, text)
+	}
+
+	src := []byte(m.text(lit))
+	return string(apply(src, edits)), u, nil
+}
+
+// Replaces every p.(V) assertion on the parameter p with p, failing if p is
+// used any other way.
+func (m *migrator) dropAsserts(lit *ast.FuncLit, param *ast.Ident,
+	v types.Type, replace func(ast.Node, string)) error {
+	obj := m.info.Defs[param]
+	if obj == nil {
+		return nil
+	}
+	asserted := map[*ast.Ident]bool{}
+	var err error
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 2 && len(n.Rhs) == 1 && m.assertsOn(n.Rhs[0], obj) {
+				err = fmt.Errorf("%s is asserted with a comma-ok", param.Name)
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == 2 && len(n.Values) == 1 &&
+				m.assertsOn(n.Values[0], obj) {
+				err = fmt.Errorf("%s is asserted with a comma-ok", param.Name)
+			}
+		case *ast.TypeAssertExpr:
+			id, ok := unparen(n.X).(*ast.Ident)
+			if !ok || m.info.Uses[id] != obj {
+				return true
+			}
+			if n.Type == nil || !types.Identical(m.info.TypeOf(n.Type), v) {
+				err = fmt.Errorf("%s is asserted to a type other than %s",
+					param.Name, m.typeString(v))
+				return true
+			}
+			asserted[id] = true
+			replace(n, id.Name)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && m.info.Uses[id] == obj &&
+			!asserted[id] {
+			err = fmt.Errorf("%s is used without a type assertion", param.Name)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func (m *migrator) assertsOn(expr ast.Expr, obj types.Object) bool {
+	a, ok := unparen(expr).(*ast.TypeAssertExpr)
+	if !ok {
+		return false
+	}
+	id, ok := unparen(a.X).(*ast.Ident)
+	return ok && m.info.Uses[id] == obj
+}
+
+// Returns the type returned by every return statement of the literal.
+func (m *migrator) resultType(lit *ast.FuncLit) (types.Type, error) {
+	var u types.Type
+	var err error
+	hasNil := false
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != 1 {
+				err = errors.New("the mapper has a bare return")
+				return false
+			}
+			tv := m.info.Types[n.Results[0]]
+			if tv.IsNil() {
+				hasNil = true
+				return true
+			}
+			t := types.Default(tv.Type)
+			if u == nil {
+				u = t
+			} else if !types.Identical(u, t) {
+				err = fmt.Errorf("the mapper returns both %s and %s",
+					m.typeString(u), m.typeString(t))
+			}
+		}
+		return err == nil
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case u == nil:
+		return nil, errors.New("the mapper result type cannot be inferred")
+	case isEmptyInterface(u):
+		return nil, errors.New("the mapper returns an interface{} value")
+	case hasNil && !nilable(u):
+		return nil, fmt.Errorf("the mapper returns nil and %s",
+			m.typeString(u))
+	}
+	return u, nil
+}
+
+// Returns the name of the constructor called, or the empty string if the
+// call is not to Of, OfNilable or OfErrorable.
+func (m *migrator) constructor(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := m.info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != optionalPath ||
+		fn.Type().(*types.Signature).Recv() != nil {
+		return ""
+	}
+	switch fn.Name() {
+	case "Of", "OfNilable", "OfErrorable":
+		return fn.Name()
+	}
+	return ""
+}
+
+// Returns the name of the *Optional method called, or the empty string if
+// the call is not to an *Optional method.
+func (m *migrator) method(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := m.info.Uses[sel.Sel].(*types.Func)
+	if !ok {
+		return ""
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return ""
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil ||
+		named.Obj().Pkg().Path() != optionalPath ||
+		named.Obj().Name() != "Optional" {
+		return ""
+	}
+	return fn.Name()
+}
+
+// Returns the type as written in the file, or the empty string if it refers
+// to a package the file does not import.
+func (m *migrator) typeString(t types.Type) string {
+	ok := true
+	s := types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == m.pkg {
+			return ""
+		}
+		name, found := m.imports[pkg.Path()]
+		if !found {
+			ok = false
+		}
+		return name
+	})
+	if !ok {
+		return ""
+	}
+	return s
+}
+
+func isEmptyInterface(t types.Type) bool {
+	i, ok := t.Underlying().(*types.Interface)
+	return ok && i.Empty()
+}
+
+func nilable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Map, *types.Slice,
+		*types.Chan, *types.Signature:
+		return true
+	}
+	return false
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
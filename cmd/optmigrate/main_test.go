@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Writes a module requiring the optional package from this repository, with
+// a.go copied from testdata/a.go.input, and returns its directory.
+func testModule(t *testing.T) string {
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := os.ReadFile(filepath.Join("testdata", "a.go.input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/a\n\ngo 1.21\n\n" +
+			"require github.com/MercuryThePlanet/optional v0.0.0\n\n" +
+			"replace github.com/MercuryThePlanet/optional => " + root + "\n",
+		"a.go": string(input),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content),
+			0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func Test_Migrate(t *testing.T) {
+	t.Run("Migrate", Migrate_test)
+	t.Run("Migrate reports", MigrateReports_test)
+	t.Run("Migrate diff", MigrateDiff_test)
+}
+
+func Migrate_test(t *testing.T) {
+	dir := testModule(t)
+	if err := migrateDir(dir, true, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "a.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("testdata", "a.go.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Migrated source differs from the golden file:\n%s",
+			unifiedDiff("a.go", want, got))
+	}
+}
+
+func MigrateReports_test(t *testing.T) {
+	dir := testModule(t)
+	var stderr bytes.Buffer
+	if err := migrateDir(dir, true, &bytes.Buffer{}, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	reports := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	expected := []string{
+		"a.go:50:9: left alone: the *Optional is used outside a single chain",
+		"a.go:54:9: left alone: OfNilable is called with an interface{} value",
+		"a.go:58:9: left alone: the mapper returns both string and int",
+		"a.go:67:9: left alone: t is used without a type assertion",
+	}
+	if len(reports) != len(expected) {
+		t.Fatalf("Expected %d reports, got:\n%s", len(expected), stderr.String())
+	}
+	for i, e := range expected {
+		if !strings.HasSuffix(reports[i], "/"+e) {
+			t.Errorf("Expected report `%v`, got `%v`", e, reports[i])
+		}
+	}
+}
+
+func MigrateDiff_test(t *testing.T) {
+	dir := testModule(t)
+	var stdout bytes.Buffer
+	if err := migrateDir(dir, false, &stdout, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	diff := stdout.String()
+	for _, line := range []string{
+		"-	return op.Of(s).IsPresent()\n",
+		"+	return op.OptionOf(s).IsPresent()\n",
+	} {
+		if !strings.Contains(diff, line) {
+			t.Errorf("Diff should contain `%v`, got:\n%s", line, diff)
+		}
+	}
+	input, _ := os.ReadFile(filepath.Join("testdata", "a.go.input"))
+	if got, _ := os.ReadFile(filepath.Join(dir, "a.go")); !bytes.Equal(got, input) {
+		t.Error("The source should not be written without -w.")
+	}
+}
+
+func Test_UnifiedDiff(t *testing.T) {
+	if d := unifiedDiff("f", []byte("a\n"), []byte("a\n")); d != "" {
+		t.Errorf("Expected no diff, got `%v`", d)
+	}
+	a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n")
+	b := []byte("1\n2\n3\n4\n5\nsix\n7\n8\n9\n10\n")
+	expected := "--- f\n+++ f\n@@ -3,7 +3,7 @@\n 3\n 4\n 5\n-6\n+six\n 7\n 8\n 9\n"
+	if d := unifiedDiff("f", a, b); d != expected {
+		t.Errorf("Expected:\n%v\ngot:\n%v", expected, d)
+	}
+}
@@ -0,0 +1,387 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	op "github.com/MercuryThePlanet/optional"
+	"math"
+	"reflect"
+	"unicode/utf8"
+)
+
+// CBOR major types.
+const (
+	cborUint byte = iota
+	cborNegint
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const (
+	cborFalse     = 0xf4
+	cborTrue      = 0xf5
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+	cborFloat16   = 0xf9
+	cborFloat32   = 0xfa
+	cborFloat64   = 0xfb
+	cborBreak     = 0xff
+)
+
+// A CBORTag is a tagged data item. Tags are decoded into a CBORTag and a
+// CBORTag is encoded with its tag number, so they survive round trips.
+type CBORTag struct {
+	Number  uint64
+	Content interface{}
+}
+
+// Returns the CBOR encoding of the Optional: null if it is empty, otherwise
+// the encoding of its value.
+//
+// Maps are encoded with their keys in bytewise order, and floats in their
+// shortest exact form, as RFC 8949 section 4.2 recommends.
+func MarshalCBOR(o *op.Optional) ([]byte, error) {
+	return encodeCBOR(nil, reflect.ValueOf(o))
+}
+
+// Decodes a single CBOR data item into o. Null and undefined empty the
+// Optional.
+func UnmarshalCBOR(data []byte, o *op.Optional) error {
+	v, err := decodeCBOR(data)
+	if err != nil {
+		return err
+	}
+	setOptional(o, v)
+	return nil
+}
+
+// Decodes a single CBOR data item into an Optional holding a value of type V.
+// Null and undefined decode into an empty Optional.
+func UnmarshalCBORAs[V any](data []byte) (*op.Optional, error) {
+	return unmarshalAs[V](data, decodeCBOR)
+}
+
+func cborHead(buf []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(buf, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(buf, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, major|27), n)
+	}
+}
+
+func cborFloat(buf []byte, f float64) []byte {
+	if math.IsNaN(f) {
+		return append(buf, cborFloat16, 0x7e, 0x00)
+	}
+	if f32 := float32(f); float64(f32) == f {
+		if h, ok := toFloat16(f32); ok {
+			return binary.BigEndian.AppendUint16(append(buf, cborFloat16), h)
+		}
+		return binary.BigEndian.AppendUint32(append(buf, cborFloat32),
+			math.Float32bits(f32))
+	}
+	return binary.BigEndian.AppendUint64(append(buf, cborFloat64),
+		math.Float64bits(f))
+}
+
+// Returns the half precision encoding of f, if f can be represented exactly.
+func toFloat16(f float32) (uint16, bool) {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23) & 0xff
+	mant := bits & 0x7fffff
+	switch {
+	case exp == 0 && mant == 0:
+		return sign, true
+	case exp == 0xff && mant == 0:
+		return sign | 0x7c00, true
+	case exp == 0 || exp == 0xff:
+		return 0, false
+	}
+	switch e := exp - 127; {
+	case e >= -14 && e <= 15 && mant&0x1fff == 0:
+		return sign | uint16(e+15)<<10 | uint16(mant>>13), true
+	case e >= -24 && e < -14:
+		full, shift := mant|1<<23, uint(-e-1)
+		if full&(1<<shift-1) != 0 {
+			return 0, false
+		}
+		return sign | uint16(full>>shift), true
+	}
+	return 0, false
+}
+
+func fromFloat16(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant != 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(sign))
+	}
+	return sign * math.Ldexp(mant+1024, exp-25)
+}
+
+func encodeCBOR(buf []byte, v reflect.Value) ([]byte, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return append(buf, cborNull), nil
+	}
+	if tag, ok := v.Interface().(CBORTag); ok {
+		return encodeCBOR(cborHead(buf, cborTag, tag.Number),
+			reflect.ValueOf(tag.Content))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, cborTrue), nil
+		}
+		return append(buf, cborFalse), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := v.Int(); i < 0 {
+			return cborHead(buf, cborNegint, uint64(-1-i)), nil
+		}
+		return cborHead(buf, cborUint, uint64(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return cborHead(buf, cborUint, v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cborFloat(buf, v.Float()), nil
+	case reflect.String:
+		return append(cborHead(buf, cborText, uint64(v.Len())), v.String()...), nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			buf = cborHead(buf, cborBytes, uint64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				buf = append(buf, byte(v.Index(i).Uint()))
+			}
+			return buf, nil
+		}
+		buf = cborHead(buf, cborArray, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if buf, err = encodeCBOR(buf, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Map:
+		return encodeMap(cborHead(buf, cborMap, uint64(v.Len())), v, encodeCBOR)
+	case reflect.Struct:
+		m := structMap(v)
+		return encodeMap(cborHead(buf, cborMap, uint64(m.Len())), m, encodeCBOR)
+	}
+	return nil, fmt.Errorf("codec: cannot encode %v as CBOR", v.Type())
+}
+
+func decodeCBOR(data []byte) (interface{}, error) {
+	d := cborDecoder{data: data}
+	v, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.data) {
+		return nil, errTrailing
+	}
+	return v, nil
+}
+
+type cborDecoder struct {
+	data  []byte
+	off   int
+	depth int
+}
+
+func (d *cborDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.off < n {
+		return nil, errTruncated
+	}
+	b := d.data[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// Reads an initial byte and its argument. Indefinite lengths are reported
+// with indefinite set.
+func (d *cborDecoder) head() (major, info byte, n uint64, indefinite bool,
+	err error) {
+	b, err := d.next(1)
+	if err != nil {
+		return
+	}
+	major, info = b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info <= 27:
+		var arg []byte
+		if arg, err = d.next(1 << (info - 24)); err != nil {
+			return
+		}
+		for _, c := range arg {
+			n = n<<8 | uint64(c)
+		}
+	case info == 31 && major >= cborBytes && major <= cborMap:
+		indefinite = true
+	case info == 31 && major == cborSimple:
+		err = fmt.Errorf("codec: unexpected CBOR break")
+	default:
+		err = fmt.Errorf("codec: invalid CBOR initial byte %#x", b[0])
+	}
+	return
+}
+
+func (d *cborDecoder) isBreak() bool {
+	if d.off < len(d.data) && d.data[d.off] == cborBreak {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func (d *cborDecoder) length(n uint64) (int, error) {
+	if n > uint64(len(d.data)-d.off) {
+		return 0, errTruncated
+	}
+	return int(n), nil
+}
+
+func (d *cborDecoder) decode() (interface{}, error) {
+	if d.depth++; d.depth > maxDepth {
+		return nil, errDepth
+	}
+	defer func() { d.depth-- }()
+
+	major, info, n, indefinite, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborUint:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case cborNegint:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("codec: CBOR integer -1-%d overflows int64", n)
+		}
+		return -1 - int64(n), nil
+	case cborBytes, cborText:
+		var b []byte
+		if indefinite {
+			b = []byte{}
+			for !d.isBreak() {
+				chunk, err := d.decode()
+				if err != nil {
+					return nil, err
+				}
+				switch c := chunk.(type) {
+				case []byte:
+					if major != cborBytes {
+						return nil, fmt.Errorf("codec: invalid CBOR text chunk")
+					}
+					b = append(b, c...)
+				case string:
+					if major != cborText {
+						return nil, fmt.Errorf("codec: invalid CBOR byte string chunk")
+					}
+					b = append(b, c...)
+				default:
+					return nil, fmt.Errorf("codec: invalid CBOR string chunk %T", chunk)
+				}
+			}
+		} else {
+			l, err := d.length(n)
+			if err != nil {
+				return nil, err
+			}
+			raw, _ := d.next(l)
+			b = append([]byte{}, raw...)
+		}
+		if major == cborBytes {
+			return b, nil
+		}
+		if !utf8.Valid(b) {
+			return nil, fmt.Errorf("codec: invalid UTF-8 in CBOR text string")
+		}
+		return string(b), nil
+	case cborArray:
+		items := []interface{}{}
+		for i := uint64(0); indefinite || i < n; i++ {
+			if indefinite && d.isBreak() {
+				break
+			}
+			item, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case cborMap:
+		m := map[interface{}]interface{}{}
+		for i := uint64(0); indefinite || i < n; i++ {
+			if indefinite && d.isBreak() {
+				break
+			}
+			k, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if k != nil && !reflect.ValueOf(k).Comparable() {
+				return nil, fmt.Errorf("codec: unsupported CBOR map key %T", k)
+			}
+			v, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		return m, nil
+	case cborTag:
+		content, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		return CBORTag{Number: n, Content: content}, nil
+	}
+
+	switch info {
+	case cborFalse & 0x1f:
+		return false, nil
+	case cborTrue & 0x1f:
+		return true, nil
+	case cborNull & 0x1f, cborUndefined & 0x1f:
+		return nil, nil
+	case cborFloat16 & 0x1f:
+		return fromFloat16(uint16(n)), nil
+	case cborFloat32 & 0x1f:
+		return float64(math.Float32frombits(uint32(n))), nil
+	case cborFloat64 & 0x1f:
+		return math.Float64frombits(n), nil
+	}
+	return nil, fmt.Errorf("codec: unsupported CBOR simple value %d", n)
+}
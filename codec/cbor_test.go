@@ -0,0 +1,287 @@
+package codec_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	op "github.com/MercuryThePlanet/optional"
+	"github.com/MercuryThePlanet/optional/codec"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type I = []interface{}
+type M = map[interface{}]interface{}
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// Examples from RFC 8949 Appendix A that round trip through the generic data
+// model: decoding the hex yields the value and encoding the value yields the
+// hex.
+var cborExamples = []struct {
+	value interface{}
+	hex   string
+}{
+	{int64(0), "00"},
+	{int64(1), "01"},
+	{int64(10), "0a"},
+	{int64(23), "17"},
+	{int64(24), "1818"},
+	{int64(25), "1819"},
+	{int64(100), "1864"},
+	{int64(1000), "1903e8"},
+	{int64(1000000), "1a000f4240"},
+	{int64(1000000000000), "1b000000e8d4a51000"},
+	{uint64(18446744073709551615), "1bffffffffffffffff"},
+	{int64(-1), "20"},
+	{int64(-10), "29"},
+	{int64(-100), "3863"},
+	{int64(-1000), "3903e7"},
+	{0.0, "f90000"},
+	{math.Copysign(0, -1), "f98000"},
+	{1.0, "f93c00"},
+	{1.1, "fb3ff199999999999a"},
+	{1.5, "f93e00"},
+	{65504.0, "f97bff"},
+	{100000.0, "fa47c35000"},
+	{3.4028234663852886e+38, "fa7f7fffff"},
+	{1.0e+300, "fb7e37e43c8800759c"},
+	{5.960464477539063e-8, "f90001"},
+	{0.00006103515625, "f90400"},
+	{-4.0, "f9c400"},
+	{-4.1, "fbc010666666666666"},
+	{math.Inf(1), "f97c00"},
+	{math.NaN(), "f97e00"},
+	{math.Inf(-1), "f9fc00"},
+	{false, "f4"},
+	{true, "f5"},
+	{codec.CBORTag{0, "2013-03-21T20:04:00Z"},
+		"c074323031332d30332d32315432303a30343a30305a"},
+	{codec.CBORTag{1, int64(1363896240)}, "c11a514b67b0"},
+	{codec.CBORTag{1, 1363896240.5}, "c1fb41d452d9ec200000"},
+	{codec.CBORTag{23, []byte{1, 2, 3, 4}}, "d74401020304"},
+	{codec.CBORTag{24, []byte("dIETF")}, "d818456449455446"},
+	{codec.CBORTag{32, "http://www.example.com"},
+		"d82076687474703a2f2f7777772e6578616d706c652e636f6d"},
+	{[]byte{}, "40"},
+	{[]byte{1, 2, 3, 4}, "4401020304"},
+	{"", "60"},
+	{"a", "6161"},
+	{"IETF", "6449455446"},
+	{"\"\\", "62225c"},
+	{"ü", "62c3bc"},
+	{"水", "63e6b0b4"},
+	{"\U00010151", "64f0908591"},
+	{I{}, "80"},
+	{I{int64(1), int64(2), int64(3)}, "83010203"},
+	{I{int64(1), I{int64(2), int64(3)}, I{int64(4), int64(5)}},
+		"8301820203820405"},
+	{I{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6), int64(7),
+		int64(8), int64(9), int64(10), int64(11), int64(12), int64(13),
+		int64(14), int64(15), int64(16), int64(17), int64(18), int64(19),
+		int64(20), int64(21), int64(22), int64(23), int64(24), int64(25)},
+		"98190102030405060708090a0b0c0d0e0f101112131415161718181819"},
+	{M{}, "a0"},
+	{M{int64(1): int64(2), int64(3): int64(4)}, "a201020304"},
+	{M{"a": int64(1), "b": I{int64(2), int64(3)}}, "a26161016162820203"},
+	{I{"a", M{"b": "c"}}, "826161a161626163"},
+	{M{"a": "A", "b": "B", "c": "C", "d": "D", "e": "E"},
+		"a56161614161626142616361436164614461656145"},
+}
+
+// Examples from RFC 8949 Appendix A that only decode, since they use
+// indefinite lengths or undefined.
+var cborDecodeExamples = []struct {
+	hex   string
+	value interface{}
+}{
+	{"5f42010243030405ff", []byte{1, 2, 3, 4, 5}},
+	{"7f657374726561646d696e67ff", "streaming"},
+	{"9fff", I{}},
+	{"9f018202039f0405ffff", I{int64(1), I{int64(2), int64(3)},
+		I{int64(4), int64(5)}}},
+	{"9f01820203820405ff", I{int64(1), I{int64(2), int64(3)},
+		I{int64(4), int64(5)}}},
+	{"83018202039f0405ff", I{int64(1), I{int64(2), int64(3)},
+		I{int64(4), int64(5)}}},
+	{"83019f0203ff820405", I{int64(1), I{int64(2), int64(3)},
+		I{int64(4), int64(5)}}},
+	{"bf61610161629f0203ffff", M{"a": int64(1), "b": I{int64(2), int64(3)}}},
+	{"826161bf61626163ff", I{"a", M{"b": "c"}}},
+	{"bf6346756ef563416d7421ff", M{"Fun": true, "Amt": int64(-2)}},
+	{"f6", nil},
+	{"f7", nil},
+}
+
+func equal(a, b interface{}) bool {
+	if fa, ok := a.(float64); ok && math.IsNaN(fa) {
+		fb, ok := b.(float64)
+		return ok && math.IsNaN(fb)
+	}
+	if fa, ok := a.(float64); ok && fa == 0 {
+		fb, ok := b.(float64)
+		return ok && fb == 0 && math.Signbit(fa) == math.Signbit(fb)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func Test_CBOR(t *testing.T) {
+	t.Run("MarshalCBOR RFC 8949 examples", MarshalCBORExamples_test)
+	t.Run("UnmarshalCBOR RFC 8949 examples", UnmarshalCBORExamples_test)
+	t.Run("MarshalCBOR empty", MarshalCBOREmpty_test)
+	t.Run("MarshalCBOR nested optionals", MarshalCBORNested_test)
+	t.Run("CBOR structs", CBORStruct_test)
+	t.Run("UnmarshalCBORAs", UnmarshalCBORAs_test)
+	t.Run("UnmarshalCBOR invalid", UnmarshalCBORInvalid_test)
+	t.Run("UnmarshalCBOR nesting depth", UnmarshalCBORDepth_test)
+}
+
+func MarshalCBORExamples_test(t *testing.T) {
+	for _, e := range cborExamples {
+		b, err := codec.MarshalCBOR(op.Of(e.value))
+		if err != nil {
+			t.Errorf("Encoding `%v`: %v", e.value, err)
+		} else if h := hex.EncodeToString(b); h != e.hex {
+			t.Errorf("Encoding `%v`: expected `%v`, got `%v`", e.value, e.hex, h)
+		}
+	}
+}
+
+func UnmarshalCBORExamples_test(t *testing.T) {
+	for _, e := range cborExamples {
+		o := op.Empty()
+		if err := codec.UnmarshalCBOR(unhex(t, e.hex), o); err != nil {
+			t.Errorf("Decoding `%v`: %v", e.hex, err)
+		} else if !equal(o.Get(), e.value) {
+			t.Errorf("Decoding `%v`: expected `%#v`, got `%#v`", e.hex, e.value,
+				o.Get())
+		}
+	}
+	for _, e := range cborDecodeExamples {
+		o := op.Of(0)
+		if err := codec.UnmarshalCBOR(unhex(t, e.hex), o); err != nil {
+			t.Errorf("Decoding `%v`: %v", e.hex, err)
+		} else if !equal(o.Get(), e.value) {
+			t.Errorf("Decoding `%v`: expected `%#v`, got `%#v`", e.hex, e.value,
+				o.Get())
+		}
+	}
+}
+
+func MarshalCBOREmpty_test(t *testing.T) {
+	b, err := codec.MarshalCBOR(op.Empty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, []byte{0xf6}) {
+		t.Errorf("Expected null, got `%x`", b)
+	}
+
+	o := op.Of(1)
+	if err := codec.UnmarshalCBOR(b, o); err != nil {
+		t.Fatal(err)
+	}
+	if o.IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+func MarshalCBORNested_test(t *testing.T) {
+	b, err := codec.MarshalCBOR(op.Of(map[string]*op.Optional{
+		"a": op.Of(1),
+		"b": op.Empty(),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := hex.EncodeToString(b); h != "a26161016162f6" {
+		t.Errorf("Expected `%v`, got `%v`", "a26161016162f6", h)
+	}
+}
+
+type point struct {
+	X, Y  int
+	Label *op.Optional
+	note  string
+}
+
+func CBORStruct_test(t *testing.T) {
+	b, err := codec.MarshalCBOR(op.Of(point{1, 2, op.Empty(), "unexported"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := "a3615801615902654c6162656cf6"
+	if hex.EncodeToString(b) != h {
+		t.Errorf("Expected `%v`, got `%x`", h, b)
+	}
+
+	o, err := codec.UnmarshalCBORAs[point](b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := o.Get().(point); p.X != 1 || p.Y != 2 || p.Label.IsPresent() {
+		t.Errorf("Expected {1 2 empty}, got `%v`", p)
+	}
+
+	o, err = codec.UnmarshalCBORAs[point](unhex(t, "a2615803654c6162656c6161"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := o.Get().(point); p.X != 3 || p.Y != 0 || p.Label.Get() != "a" {
+		t.Errorf("Expected {3 0 a}, got `%v`", p)
+	}
+	if _, err := codec.UnmarshalCBORAs[point](unhex(t, "a1615861")); err == nil {
+		t.Error("Expected a conversion error.")
+	}
+}
+
+func UnmarshalCBORAs_test(t *testing.T) {
+	o, err := codec.UnmarshalCBORAs[map[string][]uint16](
+		unhex(t, "a26161820102616280"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]uint16{"a": {1, 2}, "b": {}}
+	if !reflect.DeepEqual(o.Get(), expected) {
+		t.Errorf("Expected `%v`, got `%v`", expected, o.Get())
+	}
+
+	if o, err := codec.UnmarshalCBORAs[int](unhex(t, "f6")); err != nil ||
+		o.IsPresent() {
+		t.Errorf("Expected an empty Optional, got `%v`, %v", o.Get(), err)
+	}
+	if _, err := codec.UnmarshalCBORAs[uint8](unhex(t, "1903e8")); err == nil {
+		t.Error("Expected an overflow error.")
+	}
+	if _, err := codec.UnmarshalCBORAs[string](unhex(t, "01")); err == nil {
+		t.Error("Expected a conversion error.")
+	}
+}
+
+func UnmarshalCBORInvalid_test(t *testing.T) {
+	for _, h := range []string{"", "18", "62c3", "0101", "ff", "1c", "f0",
+		"a18001", "a1c1410101", "3bffffffffffffffff", "62c328"} {
+		if err := codec.UnmarshalCBOR(unhex(t, h), op.Empty()); err == nil {
+			t.Errorf("Expected an error decoding `%v`.", h)
+		}
+	}
+}
+
+func UnmarshalCBORDepth_test(t *testing.T) {
+	o := op.Empty()
+	ok := append(bytes.Repeat([]byte{0x81}, 999), 0x01)
+	if err := codec.UnmarshalCBOR(ok, o); err != nil || !o.IsPresent() {
+		t.Errorf("Expected 999 nested arrays to decode, got %v", err)
+	}
+
+	deep := append(bytes.Repeat([]byte{0x81}, 1<<20), 0x01)
+	if err := codec.UnmarshalCBOR(deep, op.Empty()); err == nil {
+		t.Error("Expected an error for deeply nested arrays.")
+	}
+}
@@ -0,0 +1,263 @@
+// package codec encodes and decodes optionals to and from CBOR (RFC 8949) and
+// MessagePack using only the standard library.
+//
+// Both formats have a native null, which an empty Optional is mapped to. A
+// present Optional is encoded as its value, so optional fields survive round
+// trips through other languages' implementations. Structs are encoded as maps
+// keyed by the names of their exported fields.
+//
+// Values decode into a generic data model: nil, bool, int64 (uint64 for
+// integers above math.MaxInt64), float64, string, []byte, []interface{} and
+// map[interface{}]interface{}. The UnmarshalCBORAs and UnmarshalMsgpackAs
+// functions convert the decoded value into a concrete type.
+package codec
+
+import (
+	"errors"
+	"fmt"
+	op "github.com/MercuryThePlanet/optional"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// The deepest nesting of arrays, maps and tags a decoder will follow before
+// giving up, so hostile input cannot exhaust the stack.
+const maxDepth = 1000
+
+var (
+	errTruncated = errors.New("codec: unexpected end of data")
+	errTrailing  = errors.New("codec: trailing data after value")
+	errDepth     = errors.New("codec: maximum nesting depth exceeded")
+	typeOptional = reflect.TypeOf((*op.Optional)(nil))
+)
+
+// Calls encode for each entry of the map, in the bytewise order of the
+// encoded keys.
+func encodeMap(buf []byte, v reflect.Value,
+	encode func([]byte, reflect.Value) ([]byte, error)) ([]byte, error) {
+	type entry struct{ key, value []byte }
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k, err := encode(nil, iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		e, err := encode(nil, iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{k, e})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return string(entries[i].key) < string(entries[j].key)
+	})
+	for _, e := range entries {
+		buf = append(append(buf, e.key...), e.value...)
+	}
+	return buf, nil
+}
+
+// Returns the exported fields of the struct v as a map keyed by field name.
+func structMap(v reflect.Value) reflect.Value {
+	m := make(map[string]interface{}, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Type().Field(i); f.IsExported() {
+			m[f.Name] = v.Field(i).Interface()
+		}
+	}
+	return reflect.ValueOf(m)
+}
+
+// Returns the value held by v, unwrapping interfaces, pointers and optionals.
+// A nil pointer or empty Optional is returned as an invalid reflect.Value.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		if v.Type() == typeOptional {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = reflect.ValueOf(v.Interface().(*op.Optional).Get())
+			continue
+		}
+		switch v.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		default:
+			return v
+		}
+	}
+	return v
+}
+
+// Sets o to describe v, which is empty if v is nil.
+func setOptional(o *op.Optional, v interface{}) {
+	*o = *op.OfNilable(v)
+}
+
+// Converts a value of the generic data model into typ.
+func convert(v interface{}, typ reflect.Type) (reflect.Value, error) {
+	switch typ {
+	case typeOptional:
+		return reflect.ValueOf(op.OfNilable(v)), nil
+	case typeOptional.Elem():
+		return reflect.ValueOf(op.OfNilable(v)).Elem(), nil
+	}
+	out := reflect.New(typ).Elem()
+	if v == nil {
+		return out, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(typ) {
+		out.Set(rv)
+		return out, nil
+	}
+	if tag, ok := v.(CBORTag); ok {
+		return convert(tag.Content, typ)
+	}
+
+	fail := func() (reflect.Value, error) {
+		return reflect.Value{}, fmt.Errorf("codec: cannot convert %T to %v", v, typ)
+	}
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch x := v.(type) {
+		case int64:
+			i = x
+		case uint64:
+			if x > math.MaxInt64 {
+				return fail()
+			}
+			i = int64(x)
+		default:
+			return fail()
+		}
+		if out.OverflowInt(i) {
+			return fail()
+		}
+		out.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch x := v.(type) {
+		case int64:
+			if x < 0 {
+				return fail()
+			}
+			u = uint64(x)
+		case uint64:
+			u = x
+		default:
+			return fail()
+		}
+		if out.OverflowUint(u) {
+			return fail()
+		}
+		out.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		switch x := v.(type) {
+		case float64:
+			out.SetFloat(x)
+		case int64:
+			out.SetFloat(float64(x))
+		case uint64:
+			out.SetFloat(float64(x))
+		default:
+			return fail()
+		}
+	case reflect.Bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fail()
+		}
+		out.SetBool(b)
+	case reflect.String:
+		switch x := v.(type) {
+		case string:
+			out.SetString(x)
+		case []byte:
+			out.SetString(string(x))
+		default:
+			return fail()
+		}
+	case reflect.Slice:
+		if b, ok := v.([]byte); ok && typ.Elem().Kind() == reflect.Uint8 {
+			out.SetBytes(append([]byte(nil), b...))
+			return out, nil
+		}
+		items, ok := v.([]interface{})
+		if !ok {
+			return fail()
+		}
+		out.Set(reflect.MakeSlice(typ, len(items), len(items)))
+		for i, item := range items {
+			e, err := convert(item, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(e)
+		}
+	case reflect.Map:
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			return fail()
+		}
+		out.Set(reflect.MakeMapWithSize(typ, len(m)))
+		for mk, mv := range m {
+			k, err := convert(mk, typ.Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			e, err := convert(mv, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.SetMapIndex(k, e)
+		}
+	case reflect.Struct:
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			return fail()
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			mv, ok := m[f.Name]
+			if !f.IsExported() || !ok {
+				continue
+			}
+			e, err := convert(mv, f.Type)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Field(i).Set(e)
+		}
+	case reflect.Ptr:
+		e, err := convert(v, typ.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.Set(reflect.New(typ.Elem()))
+		out.Elem().Set(e)
+	default:
+		return fail()
+	}
+	return out, nil
+}
+
+func unmarshalAs[V any](data []byte,
+	unmarshal func([]byte) (interface{}, error)) (*op.Optional, error) {
+	v, err := unmarshal(data)
+	if err != nil || v == nil {
+		return op.Empty(), err
+	}
+	converted, err := convert(v, reflect.TypeOf((*V)(nil)).Elem())
+	if err != nil {
+		return op.Empty(), err
+	}
+	return op.Of(converted.Interface()), nil
+}
@@ -0,0 +1,352 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	op "github.com/MercuryThePlanet/optional"
+	"math"
+	"reflect"
+)
+
+const (
+	msgpackNil     = 0xc0
+	msgpackFalse   = 0xc2
+	msgpackTrue    = 0xc3
+	msgpackBin8    = 0xc4
+	msgpackExt8    = 0xc7
+	msgpackFloat32 = 0xca
+	msgpackFloat64 = 0xcb
+	msgpackUint8   = 0xcc
+	msgpackInt8    = 0xd0
+	msgpackFixext1 = 0xd4
+	msgpackStr8    = 0xd9
+	msgpackArray16 = 0xdc
+	msgpackMap16   = 0xde
+)
+
+// A MsgpackExt is a MessagePack extension value. Extensions, including the
+// timestamp extension, are decoded into a MsgpackExt and a MsgpackExt is
+// encoded with its type, so they survive round trips.
+type MsgpackExt struct {
+	Type int8
+	Data []byte
+}
+
+// Returns the MessagePack encoding of the Optional: nil if it is empty,
+// otherwise the encoding of its value.
+//
+// Integers are encoded in their smallest form and maps with their keys in
+// bytewise order.
+func MarshalMsgpack(o *op.Optional) ([]byte, error) {
+	return encodeMsgpack(nil, reflect.ValueOf(o))
+}
+
+// Decodes a single MessagePack value into o. Nil empties the Optional.
+func UnmarshalMsgpack(data []byte, o *op.Optional) error {
+	v, err := decodeMsgpack(data)
+	if err != nil {
+		return err
+	}
+	setOptional(o, v)
+	return nil
+}
+
+// Decodes a single MessagePack value into an Optional holding a value of type
+// V. Nil decodes into an empty Optional.
+func UnmarshalMsgpackAs[V any](data []byte) (*op.Optional, error) {
+	return unmarshalAs[V](data, decodeMsgpack)
+}
+
+// Appends a length-prefixed header: the fix form if n fits in fixBits,
+// otherwise the 8 (if first8 is non-zero), 16 or 32 bit form.
+func msgpackLength(buf []byte, fix byte, fixBits uint, first8, first16 byte,
+	n int) []byte {
+	switch {
+	case fixBits > 0 && n < 1<<fixBits:
+		return append(buf, fix|byte(n))
+	case first8 != 0 && n <= math.MaxUint8:
+		return append(buf, first8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, first16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(buf, first16+1), uint32(n))
+	}
+}
+
+func msgpackUint(buf []byte, u uint64) []byte {
+	switch {
+	case u < 0x80:
+		return append(buf, byte(u))
+	case u <= math.MaxUint8:
+		return append(buf, msgpackUint8, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, msgpackUint8+1), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, msgpackUint8+2), uint32(u))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, msgpackUint8+3), u)
+	}
+}
+
+func msgpackInt(buf []byte, i int64) []byte {
+	switch {
+	case i >= 0:
+		return msgpackUint(buf, uint64(i))
+	case i >= -32:
+		return append(buf, byte(i))
+	case i >= math.MinInt8:
+		return append(buf, msgpackInt8, byte(i))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(buf, msgpackInt8+1), uint16(i))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(buf, msgpackInt8+2), uint32(i))
+	default:
+		return binary.BigEndian.AppendUint64(append(buf, msgpackInt8+3), uint64(i))
+	}
+}
+
+func encodeMsgpack(buf []byte, v reflect.Value) ([]byte, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return append(buf, msgpackNil), nil
+	}
+	if ext, ok := v.Interface().(MsgpackExt); ok {
+		switch n := len(ext.Data); n {
+		case 1, 2, 4, 8, 16:
+			var size byte
+			for 1<<size < n {
+				size++
+			}
+			buf = append(buf, msgpackFixext1+size)
+		default:
+			buf = msgpackLength(buf, 0, 0, msgpackExt8, msgpackExt8+1, n)
+		}
+		return append(append(buf, byte(ext.Type)), ext.Data...), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(buf, msgpackTrue), nil
+		}
+		return append(buf, msgpackFalse), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return msgpackInt(buf, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return msgpackUint(buf, v.Uint()), nil
+	case reflect.Float32:
+		return binary.BigEndian.AppendUint32(append(buf, msgpackFloat32),
+			math.Float32bits(float32(v.Float()))), nil
+	case reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(buf, msgpackFloat64),
+			math.Float64bits(v.Float())), nil
+	case reflect.String:
+		buf = msgpackLength(buf, 0xa0, 5, msgpackStr8, msgpackStr8+1, v.Len())
+		return append(buf, v.String()...), nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			buf = msgpackLength(buf, 0, 0, msgpackBin8, msgpackBin8+1, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				buf = append(buf, byte(v.Index(i).Uint()))
+			}
+			return buf, nil
+		}
+		buf = msgpackLength(buf, 0x90, 4, 0, msgpackArray16, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if buf, err = encodeMsgpack(buf, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case reflect.Map:
+		return encodeMap(msgpackLength(buf, 0x80, 4, 0, msgpackMap16, v.Len()),
+			v, encodeMsgpack)
+	case reflect.Struct:
+		m := structMap(v)
+		return encodeMap(msgpackLength(buf, 0x80, 4, 0, msgpackMap16, m.Len()),
+			m, encodeMsgpack)
+	}
+	return nil, fmt.Errorf("codec: cannot encode %v as MessagePack", v.Type())
+}
+
+func decodeMsgpack(data []byte) (interface{}, error) {
+	d := msgpackDecoder{data: data}
+	v, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(d.data) {
+		return nil, errTrailing
+	}
+	return v, nil
+}
+
+type msgpackDecoder struct {
+	data  []byte
+	off   int
+	depth int
+}
+
+func (d *msgpackDecoder) next(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.off < n {
+		return nil, errTruncated
+	}
+	b := d.data[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+// Reads a big-endian unsigned integer of size bytes.
+func (d *msgpackDecoder) uint(size int) (uint64, error) {
+	b, err := d.next(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
+	}
+	return n, nil
+}
+
+func (d *msgpackDecoder) bytes(size int) ([]byte, error) {
+	n, err := d.uint(size)
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(d.data)-d.off) {
+		return nil, errTruncated
+	}
+	b, _ := d.next(int(n))
+	return append([]byte{}, b...), nil
+}
+
+func (d *msgpackDecoder) array(n uint64) (interface{}, error) {
+	items := []interface{}{}
+	for i := uint64(0); i < n; i++ {
+		item, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (d *msgpackDecoder) mapping(n uint64) (interface{}, error) {
+	m := map[interface{}]interface{}{}
+	for i := uint64(0); i < n; i++ {
+		k, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		if k != nil && !reflect.ValueOf(k).Comparable() {
+			return nil, fmt.Errorf("codec: unsupported MessagePack map key %T", k)
+		}
+		v, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+func (d *msgpackDecoder) ext(n int) (interface{}, error) {
+	b, err := d.next(1 + n)
+	if err != nil {
+		return nil, err
+	}
+	return MsgpackExt{Type: int8(b[0]), Data: append([]byte{}, b[1:]...)}, nil
+}
+
+func (d *msgpackDecoder) decode() (interface{}, error) {
+	if d.depth++; d.depth > maxDepth {
+		return nil, errDepth
+	}
+	defer func() { d.depth-- }()
+
+	b, err := d.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+
+	switch {
+	case c < 0x80:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c < 0x90:
+		return d.mapping(uint64(c & 0x0f))
+	case c < 0xa0:
+		return d.array(uint64(c & 0x0f))
+	case c < 0xc0:
+		s, err := d.next(int(c & 0x1f))
+		return string(s), err
+	}
+
+	switch c {
+	case msgpackNil:
+		return nil, nil
+	case msgpackFalse:
+		return false, nil
+	case msgpackTrue:
+		return true, nil
+	case msgpackBin8, msgpackBin8 + 1, msgpackBin8 + 2:
+		return d.bytes(1 << (c - msgpackBin8))
+	case msgpackStr8, msgpackStr8 + 1, msgpackStr8 + 2:
+		s, err := d.bytes(1 << (c - msgpackStr8))
+		return string(s), err
+	case msgpackExt8, msgpackExt8 + 1, msgpackExt8 + 2:
+		n, err := d.uint(1 << (c - msgpackExt8))
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(d.data)) {
+			return nil, errTruncated
+		}
+		return d.ext(int(n))
+	case msgpackFixext1, msgpackFixext1 + 1, msgpackFixext1 + 2,
+		msgpackFixext1 + 3, msgpackFixext1 + 4:
+		return d.ext(1 << (c - msgpackFixext1))
+	case msgpackFloat32:
+		n, err := d.uint(4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case msgpackFloat64:
+		n, err := d.uint(8)
+		return math.Float64frombits(n), err
+	case msgpackUint8, msgpackUint8 + 1, msgpackUint8 + 2, msgpackUint8 + 3:
+		n, err := d.uint(1 << (c - msgpackUint8))
+		if err != nil {
+			return nil, err
+		}
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case msgpackInt8, msgpackInt8 + 1, msgpackInt8 + 2, msgpackInt8 + 3:
+		size := 1 << (c - msgpackInt8)
+		n, err := d.uint(size)
+		if err != nil {
+			return nil, err
+		}
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, nil
+	case msgpackArray16, msgpackArray16 + 1:
+		n, err := d.uint(2 << (c - msgpackArray16))
+		if err != nil {
+			return nil, err
+		}
+		return d.array(n)
+	case msgpackMap16, msgpackMap16 + 1:
+		n, err := d.uint(2 << (c - msgpackMap16))
+		if err != nil {
+			return nil, err
+		}
+		return d.mapping(n)
+	}
+	return nil, fmt.Errorf("codec: invalid MessagePack type byte %#x", c)
+}
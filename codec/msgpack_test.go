@@ -0,0 +1,163 @@
+package codec_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	op "github.com/MercuryThePlanet/optional"
+	"github.com/MercuryThePlanet/optional/codec"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Encodings from the MessagePack specification, covering the smallest form
+// of every format family.
+var msgpackExamples = []struct {
+	value interface{}
+	hex   string
+}{
+	{false, "c2"},
+	{true, "c3"},
+	{int64(0), "00"},
+	{int64(127), "7f"},
+	{int64(128), "cc80"},
+	{int64(256), "cd0100"},
+	{int64(65536), "ce00010000"},
+	{int64(4294967296), "cf0000000100000000"},
+	{uint64(math.MaxUint64), "cfffffffffffffffff"},
+	{int64(-1), "ff"},
+	{int64(-32), "e0"},
+	{int64(-33), "d0df"},
+	{int64(-129), "d1ff7f"},
+	{int64(-32769), "d2ffff7fff"},
+	{int64(math.MinInt64), "d38000000000000000"},
+	{1.5, "cb3ff8000000000000"},
+	{"", "a0"},
+	{"a", "a161"},
+	{strings.Repeat("a", 32), "d920" + strings.Repeat("61", 32)},
+	{strings.Repeat("a", 256), "da0100" + strings.Repeat("61", 256)},
+	{[]byte{}, "c400"},
+	{[]byte{1, 2}, "c4020102"},
+	{I{}, "90"},
+	{I{int64(1), "a"}, "9201a161"},
+	{make(I, 16), "dc0010" + strings.Repeat("c0", 16)},
+	{M{}, "80"},
+	{M{"a": int64(1), "b": I{int64(2)}}, "82a16101a1629102"},
+	{codec.MsgpackExt{-1, []byte{0, 0, 0, 1}}, "d6ff00000001"},
+	{codec.MsgpackExt{1, []byte{1, 2, 3}}, "c70301010203"},
+}
+
+func Test_Msgpack(t *testing.T) {
+	t.Run("MarshalMsgpack examples", MarshalMsgpackExamples_test)
+	t.Run("UnmarshalMsgpack examples", UnmarshalMsgpackExamples_test)
+	t.Run("MarshalMsgpack Go types", MarshalMsgpackTypes_test)
+	t.Run("MarshalMsgpack empty", MarshalMsgpackEmpty_test)
+	t.Run("UnmarshalMsgpackAs", UnmarshalMsgpackAs_test)
+	t.Run("UnmarshalMsgpack invalid", UnmarshalMsgpackInvalid_test)
+	t.Run("UnmarshalMsgpack nesting depth", UnmarshalMsgpackDepth_test)
+}
+
+func MarshalMsgpackExamples_test(t *testing.T) {
+	for _, e := range msgpackExamples {
+		b, err := codec.MarshalMsgpack(op.Of(e.value))
+		if err != nil {
+			t.Errorf("Encoding `%v`: %v", e.value, err)
+		} else if h := hex.EncodeToString(b); h != e.hex {
+			t.Errorf("Encoding `%v`: expected `%v`, got `%v`", e.value, e.hex, h)
+		}
+	}
+}
+
+func UnmarshalMsgpackExamples_test(t *testing.T) {
+	for _, e := range msgpackExamples {
+		o := op.Empty()
+		if err := codec.UnmarshalMsgpack(unhex(t, e.hex), o); err != nil {
+			t.Errorf("Decoding `%v`: %v", e.hex, err)
+		} else if !equal(o.Get(), e.value) {
+			t.Errorf("Decoding `%v`: expected `%#v`, got `%#v`", e.hex, e.value,
+				o.Get())
+		}
+	}
+}
+
+func MarshalMsgpackTypes_test(t *testing.T) {
+	values := []struct {
+		value interface{}
+		hex   string
+	}{
+		{float32(1.5), "ca3fc00000"},
+		{uint8(200), "ccc8"},
+		{int16(-2), "fe"},
+		{[2]string{"a", "b"}, "92a161a162"},
+		{map[string]*op.Optional{"a": op.Empty()}, "81a161c0"},
+		{point{X: 1, Label: op.Of("a")}, "83a15801a15900a54c6162656ca161"},
+	}
+	for _, v := range values {
+		b, err := codec.MarshalMsgpack(op.Of(v.value))
+		if err != nil {
+			t.Errorf("Encoding `%v`: %v", v.value, err)
+		} else if h := hex.EncodeToString(b); h != v.hex {
+			t.Errorf("Encoding `%v`: expected `%v`, got `%v`", v.value, v.hex, h)
+		}
+	}
+	if _, err := codec.MarshalMsgpack(op.Of(make(chan int))); err == nil {
+		t.Error("Expected an error encoding a channel.")
+	}
+}
+
+func MarshalMsgpackEmpty_test(t *testing.T) {
+	b, err := codec.MarshalMsgpack(op.Empty())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, []byte{0xc0}) {
+		t.Errorf("Expected nil, got `%x`", b)
+	}
+
+	o := op.Of(1)
+	if err := codec.UnmarshalMsgpack(b, o); err != nil {
+		t.Fatal(err)
+	}
+	if o.IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+func UnmarshalMsgpackAs_test(t *testing.T) {
+	o, err := codec.UnmarshalMsgpackAs[map[string]float32](
+		unhex(t, "82a16101a162cb3ff8000000000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float32{"a": 1, "b": 1.5}
+	if !reflect.DeepEqual(o.Get(), expected) {
+		t.Errorf("Expected `%v`, got `%v`", expected, o.Get())
+	}
+	if o, err := codec.UnmarshalMsgpackAs[*string](unhex(t, "a161")); err != nil ||
+		*o.Get().(*string) != "a" {
+		t.Errorf("Expected a pointer to `a`, got `%v`, %v", o.Get(), err)
+	}
+}
+
+func UnmarshalMsgpackInvalid_test(t *testing.T) {
+	for _, h := range []string{"", "c1", "cc", "a261", "9201", "c4ff", "0101",
+		"c7ff01", "81d4010101"} {
+		if err := codec.UnmarshalMsgpack(unhex(t, h), op.Empty()); err == nil {
+			t.Errorf("Expected an error decoding `%v`.", h)
+		}
+	}
+}
+
+func UnmarshalMsgpackDepth_test(t *testing.T) {
+	o := op.Empty()
+	ok := append(bytes.Repeat([]byte{0x91}, 999), 0x01)
+	if err := codec.UnmarshalMsgpack(ok, o); err != nil || !o.IsPresent() {
+		t.Errorf("Expected 999 nested arrays to decode, got %v", err)
+	}
+
+	deep := append(bytes.Repeat([]byte{0x91}, 1<<20), 0x01)
+	if err := codec.UnmarshalMsgpack(deep, op.Empty()); err == nil {
+		t.Error("Expected an error for deeply nested arrays.")
+	}
+}
@@ -0,0 +1,121 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"time"
+)
+
+// Returns an Optional describing the value of the environment variable, if
+// set, otherwise returns an empty Optional. A variable set to the empty string
+// is present.
+func Env(name string) *Optional {
+	if v, ok := os.LookupEnv(name); ok {
+		return Of(v)
+	}
+	return Empty()
+}
+
+// Returns an Optional describing the environment variable parsed by p, or an
+// empty Optional if the variable is unset. Parse errors name the variable.
+func EnvFunc(name string, p Parser) (*Optional, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return Empty(), nil
+	}
+	t, err := p(v)
+	if err != nil {
+		return Empty(), fmt.Errorf("optional: env %s: %w", name, err)
+	}
+	return OfNilable(t), nil
+}
+
+func envKind(name string, t T) (*Optional, error) {
+	typ := reflect.TypeOf(t)
+	o, err := EnvFunc(name, func(s string) (T, error) {
+		return parseText(s, typ)
+	})
+	o.typ = typ
+	return o, err
+}
+
+// Returns an Optional describing the environment variable parsed as an int,
+// or an empty Optional if the variable is unset.
+func EnvInt(name string) (*Optional, error) {
+	return envKind(name, 0)
+}
+
+// Returns an Optional describing the environment variable parsed as a bool,
+// or an empty Optional if the variable is unset.
+func EnvBool(name string) (*Optional, error) {
+	return envKind(name, false)
+}
+
+// Returns an Optional describing the environment variable parsed as a
+// time.Duration, or an empty Optional if the variable is unset.
+func EnvDuration(name string) (*Optional, error) {
+	return envKind(name, time.Duration(0))
+}
+
+// Returns an Optional describing the environment variable parsed as a
+// *url.URL, or an empty Optional if the variable is unset.
+func EnvURL(name string) (*Optional, error) {
+	return EnvFunc(name, func(s string) (T, error) {
+		return url.Parse(s)
+	})
+}
+
+// Fills the Optional fields of the struct pointed to by v from the
+// environment variables named by their `env:"NAME"` tags.
+//
+// Fields may be of type Optional or *Optional. Values are parsed into the
+// field's type as UnmarshalText does, so a field initialised with EmptyOf
+// decodes into that type and an untyped field holds a string. Fields whose
+// variables are unset are left as they are, so defaults set beforehand
+// survive. Every parse error is returned together, joined by errors.Join.
+func LoadEnv(v T) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optional: LoadEnv takes a struct pointer, got %T", v)
+	}
+	rv = rv.Elem()
+
+	var errs []error
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		name, ok := field.Tag.Lookup("env")
+		if !ok || name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		var o *Optional
+		switch field.Type {
+		case reflect.TypeOf(Optional{}):
+			o = rv.Field(i).Addr().Interface().(*Optional)
+		case reflect.TypeOf(o):
+			if rv.Field(i).IsNil() {
+				rv.Field(i).Set(reflect.ValueOf(Empty()))
+			}
+			o = rv.Field(i).Interface().(*Optional)
+		default:
+			errs = append(errs, fmt.Errorf(
+				"optional: env %s: field %s is not an Optional", name,
+				field.Name))
+			continue
+		}
+
+		typ := o.elemType()
+		p, err := EnvFunc(name, func(s string) (T, error) {
+			return parseText(s, typ)
+		})
+		if err != nil {
+			errs = append(errs, err)
+		} else if p.present {
+			o.set(p.t, true)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,179 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Env(t *testing.T) {
+	t.Run("Env", Env_test)
+	t.Run("Env unset", EnvUnset_test)
+	t.Run("Env set to empty", EnvEmpty_test)
+	t.Run("Env typed", EnvTyped_test)
+	t.Run("Env typed invalid", EnvTypedInvalid_test)
+}
+
+func Env_test(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST", TEST_STR)
+
+	if v := op.Env("OPTIONAL_TEST").Get(); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+}
+
+func EnvUnset_test(t *testing.T) {
+	defer shouldNotPanic("optional.Env", t)
+
+	if op.Env("OPTIONAL_TEST_UNSET").IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+	o, err := op.EnvInt("OPTIONAL_TEST_UNSET")
+	if err != nil || o.IsPresent() {
+		t.Errorf("Expected an empty Optional and no error, got `%v`, %v",
+			o.Get(), err)
+	}
+}
+
+func EnvEmpty_test(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST", "")
+
+	if v := op.Env("OPTIONAL_TEST").OrElse(nil); v != "" {
+		t.Errorf("Expected empty string, got `%v`", v)
+	}
+}
+
+func EnvTyped_test(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST_INT", TEST_STR)
+	t.Setenv("OPTIONAL_TEST_BOOL", "true")
+	t.Setenv("OPTIONAL_TEST_DURATION", "1h")
+	t.Setenv("OPTIONAL_TEST_URL", "https://example.com")
+
+	if o, err := op.EnvInt("OPTIONAL_TEST_INT"); err != nil || o.Get() != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`, %v", TEST_INT, o.Get(), err)
+	}
+	if o, err := op.EnvBool("OPTIONAL_TEST_BOOL"); err != nil || o.Get() != true {
+		t.Errorf("Expected `%v`, got `%v`, %v", true, o.Get(), err)
+	}
+	if o, err := op.EnvDuration("OPTIONAL_TEST_DURATION"); err != nil ||
+		o.Get() != time.Hour {
+		t.Errorf("Expected `%v`, got `%v`, %v", time.Hour, o.Get(), err)
+	}
+	o, err := op.EnvURL("OPTIONAL_TEST_URL")
+	if u, ok := o.Get().(*url.URL); err != nil || !ok || u.Host != "example.com" {
+		t.Errorf("Expected host `example.com`, got `%v`, %v", o.Get(), err)
+	}
+}
+
+func EnvTypedInvalid_test(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST_INT", "abc")
+
+	o, err := op.EnvInt("OPTIONAL_TEST_INT")
+	if err == nil || !strings.Contains(err.Error(), "OPTIONAL_TEST_INT") {
+		t.Errorf("Expected an error naming the variable, got %v", err)
+	}
+	if o.IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+type EnvConfig struct {
+	Host    *op.Optional `env:"OPTIONAL_TEST_HOST"`
+	Port    op.Optional  `env:"OPTIONAL_TEST_PORT"`
+	Debug   *op.Optional `env:"OPTIONAL_TEST_DEBUG"`
+	Timeout *op.Optional `env:"OPTIONAL_TEST_TIMEOUT"`
+	Ignored *op.Optional
+}
+
+func Test_LoadEnv(t *testing.T) {
+	t.Run("LoadEnv", LoadEnv_test)
+	t.Run("LoadEnv defaults", LoadEnvDefaults_test)
+	t.Run("LoadEnv errors", LoadEnvErrors_test)
+	t.Run("LoadEnv invalid target", LoadEnvInvalid_test)
+}
+
+func LoadEnv_test(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST_HOST", "localhost")
+	t.Setenv("OPTIONAL_TEST_PORT", "8080")
+
+	cfg := EnvConfig{
+		Port:    *op.EmptyOf(0),
+		Debug:   op.EmptyOf(false),
+		Timeout: op.Of(time.Second),
+	}
+	if err := op.LoadEnv(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if v := cfg.Host.Get(); v != "localhost" {
+		t.Errorf("Expected `%v`, got `%v`", "localhost", v)
+	}
+	if v := cfg.Port.Get(); v != 8080 {
+		t.Errorf("Expected `%v`, got `%v`", 8080, v)
+	}
+	if cfg.Debug.IsPresent() {
+		t.Error("Unset variables should be empty.")
+	}
+	if v := cfg.Timeout.Get(); v != time.Second {
+		t.Errorf("Expected `%v`, got `%v`", time.Second, v)
+	}
+	if cfg.Ignored != nil {
+		t.Error("Untagged fields should not be touched.")
+	}
+}
+
+func LoadEnvDefaults_test(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST_TIMEOUT", "5s")
+
+	cfg := EnvConfig{
+		Host:    op.Of("example.com"),
+		Port:    *op.Of(80),
+		Timeout: op.Of(time.Second),
+	}
+	if err := op.LoadEnv(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if v := cfg.Host.Get(); v != "example.com" {
+		t.Errorf("Expected `%v`, got `%v`", "example.com", v)
+	}
+	if v := cfg.Port.Get(); v != 80 {
+		t.Errorf("Expected `%v`, got `%v`", 80, v)
+	}
+	if v := cfg.Timeout.Get(); v != 5*time.Second {
+		t.Errorf("Expected `%v`, got `%v`", 5*time.Second, v)
+	}
+}
+
+func LoadEnvErrors_test(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST_PORT", "http")
+	t.Setenv("OPTIONAL_TEST_DEBUG", "maybe")
+
+	cfg := EnvConfig{Port: *op.EmptyOf(0), Debug: op.EmptyOf(false)}
+	err := op.LoadEnv(&cfg)
+	if err == nil {
+		t.Fatal("Expected an error.")
+	}
+	for _, name := range []string{"OPTIONAL_TEST_PORT", "OPTIONAL_TEST_DEBUG"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Error should name %s, got %v", name, err)
+		}
+	}
+	if u, ok := err.(interface{ Unwrap() []error }); !ok || len(u.Unwrap()) != 2 {
+		t.Errorf("Expected two joined errors, got %v", err)
+	}
+}
+
+func LoadEnvInvalid_test(t *testing.T) {
+	defer shouldNotPanic("optional.LoadEnv", t)
+
+	if err := op.LoadEnv(EnvConfig{}); err == nil {
+		t.Error("Expected an error for a non-pointer.")
+	}
+	var bad struct {
+		Port int `env:"OPTIONAL_TEST_PORT"`
+	}
+	if err := op.LoadEnv(&bad); err == nil {
+		t.Errorf("Expected an error for a non-Optional field, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package optional
+
+import (
+	"flag"
+	"reflect"
+	"time"
+)
+
+// flagValue implements flag.Value by parsing into an Optional, which stays
+// empty until the flag is set.
+type flagValue struct {
+	o      *Optional
+	parse  Parser
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil || f.o == nil {
+		return ""
+	}
+	text, _ := f.o.MarshalText()
+	return string(text)
+}
+
+func (f *flagValue) Set(s string) error {
+	t, err := f.parse(s)
+	if err != nil {
+		return err
+	}
+	f.o.set(t, t != nil)
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.isBool
+}
+
+func kindFlag(fs *flag.FlagSet, name, usage string, t T) *Optional {
+	o := EmptyOf(t)
+	typ := reflect.TypeOf(t)
+	fs.Var(&flagValue{o: o, parse: func(s string) (T, error) {
+		return parseText(s, typ)
+	}, isBool: typ.Kind() == reflect.Bool}, name, usage)
+	return o
+}
+
+// Defines an int flag on fs and returns an Optional that becomes present only
+// if the flag is set, so "-n=0" can be told apart from no flag at all.
+func IntFlag(fs *flag.FlagSet, name, usage string) *Optional {
+	return kindFlag(fs, name, usage, 0)
+}
+
+// Defines a string flag on fs and returns an Optional that becomes present
+// only if the flag is set. "-name=" sets it to the empty string.
+func StringFlag(fs *flag.FlagSet, name, usage string) *Optional {
+	return kindFlag(fs, name, usage, "")
+}
+
+// Defines a time.Duration flag on fs and returns an Optional that becomes
+// present only if the flag is set.
+func DurationFlag(fs *flag.FlagSet, name, usage string) *Optional {
+	return kindFlag(fs, name, usage, time.Duration(0))
+}
+
+// Defines a bool flag on fs and returns an Optional that becomes present only
+// if the flag is set. As with flag.Bool, "-name" alone sets it to true.
+func BoolFlag(fs *flag.FlagSet, name, usage string) *Optional {
+	return kindFlag(fs, name, usage, false)
+}
+
+// Defines a flag on fs whose value is parsed by the given function, and
+// returns an Optional that becomes present only if the flag is set.
+func FlagFunc[V any](fs *flag.FlagSet, name, usage string,
+	parse func(string) (V, error)) *Optional {
+	var zero V
+	o := EmptyOf(zero)
+	fs.Var(&flagValue{o: o, parse: func(s string) (T, error) {
+		v, err := parse(s)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}}, name, usage)
+	return o
+}
@@ -0,0 +1,128 @@
+package optional_test
+
+import (
+	"flag"
+	op "github.com/MercuryThePlanet/optional"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func Test_Flag(t *testing.T) {
+	t.Run("Flag unset", FlagUnset_test)
+	t.Run("Flag set to zero", FlagZero_test)
+	t.Run("Flag set", FlagSet_test)
+	t.Run("BoolFlag", BoolFlag_test)
+	t.Run("FlagFunc", FlagFunc_test)
+	t.Run("Flag invalid", FlagInvalid_test)
+	t.Run("Flag defaults", FlagDefaults_test)
+}
+
+func FlagUnset_test(t *testing.T) {
+	defer shouldNotPanic("optional.IntFlag", t)
+
+	fs := newFlagSet()
+	retries := op.IntFlag(fs, "retries", "")
+	name := op.StringFlag(fs, "name", "")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if retries.IsPresent() || name.IsPresent() {
+		t.Error("Unset flags should be empty.")
+	}
+}
+
+func FlagZero_test(t *testing.T) {
+	defer shouldNotPanic("optional.IntFlag", t)
+
+	fs := newFlagSet()
+	retries := op.IntFlag(fs, "retries", "")
+	name := op.StringFlag(fs, "name", "")
+	if err := fs.Parse([]string{"-retries=0", "-name="}); err != nil {
+		t.Fatal(err)
+	}
+	if v := retries.OrElse(-1); v != 0 {
+		t.Errorf("Expected `%v`, got `%v`", 0, v)
+	}
+	if v := name.OrElse(nil); v != "" {
+		t.Errorf("Expected empty string, got `%v`", v)
+	}
+}
+
+func FlagSet_test(t *testing.T) {
+	defer shouldNotPanic("optional.DurationFlag", t)
+
+	fs := newFlagSet()
+	retries := op.IntFlag(fs, "retries", "")
+	timeout := op.DurationFlag(fs, "timeout", "")
+	if err := fs.Parse([]string{"-retries", TEST_STR, "-timeout=2s"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := retries.Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if v := timeout.Get(); v != 2*time.Second {
+		t.Errorf("Expected `%v`, got `%v`", 2*time.Second, v)
+	}
+}
+
+func BoolFlag_test(t *testing.T) {
+	defer shouldNotPanic("optional.BoolFlag", t)
+
+	fs := newFlagSet()
+	verbose := op.BoolFlag(fs, "verbose", "")
+	dryRun := op.BoolFlag(fs, "dry-run", "")
+	if err := fs.Parse([]string{"-verbose", "-dry-run=false"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := verbose.Get(); v != true {
+		t.Errorf("Expected `%v`, got `%v`", true, v)
+	}
+	if v := dryRun.Get(); v != false {
+		t.Errorf("Expected `%v`, got `%v`", false, v)
+	}
+}
+
+func FlagFunc_test(t *testing.T) {
+	defer shouldNotPanic("optional.FlagFunc", t)
+
+	fs := newFlagSet()
+	endpoint := op.FlagFunc(fs, "endpoint", "", url.Parse)
+	if err := fs.Parse([]string{"-endpoint", "https://example.com/api"}); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := endpoint.Get().(*url.URL); !ok || v.Host != "example.com" {
+		t.Errorf("Expected host `example.com`, got `%v`", endpoint.Get())
+	}
+}
+
+func FlagInvalid_test(t *testing.T) {
+	defer shouldNotPanic("optional.IntFlag", t)
+
+	fs := newFlagSet()
+	retries := op.IntFlag(fs, "retries", "")
+	if err := fs.Parse([]string{"-retries=abc"}); err == nil {
+		t.Error("Expected a parse error.")
+	}
+	if retries.IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+func FlagDefaults_test(t *testing.T) {
+	defer shouldNotPanic("optional.IntFlag", t)
+
+	fs := newFlagSet()
+	op.IntFlag(fs, "retries", "number of retries")
+	fs.PrintDefaults()
+	if f := fs.Lookup("retries"); f.DefValue != "" {
+		t.Errorf("Expected no default value, got `%v`", f.DefValue)
+	}
+}
@@ -0,0 +1,44 @@
+package optional
+
+// Collapses any depth of nested optionals. If the value is an *Optional, the
+// Optional takes on its value, repeatedly, until the value is not an
+// *Optional. An empty or nil nested Optional empties the Optional.
+func (o *Optional) Flatten() *Optional {
+	return o.flatten().record("Flatten")
+}
+
+// Like Map, but if the result of the mapping function is an *Optional, the
+// Optional takes on its value instead, see Flatten.
+func (o *Optional) MapFlat(f Mapper) *Optional {
+	if o.present {
+		mapped_t := f(o.t)
+		return o.set(mapped_t, mapped_t != nil).flatten().record("MapFlat")
+	}
+	return o.set(nil, false).record("MapFlat")
+}
+
+func (o *Optional) flatten() *Optional {
+	var seen []*Optional
+	for o.present {
+		inner, ok := o.t.(*Optional)
+		if !ok {
+			break
+		}
+		for _, s := range seen {
+			if s == inner {
+				return o.set(nil, false)
+			}
+		}
+		seen = append(seen, inner)
+		o.take(inner)
+	}
+	return o
+}
+
+// Returns the nested Option, if present, otherwise returns an empty Option.
+func Flatten[V any](o Option[Option[V]]) Option[V] {
+	if !o.present {
+		return Option[V]{}
+	}
+	return o.v
+}
@@ -0,0 +1,120 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"strconv"
+	"testing"
+)
+
+func Test_Flatten(t *testing.T) {
+	t.Run("Flatten", Flatten_test)
+	t.Run("Flatten not nested", FlattenNotNested_test)
+	t.Run("Flatten empty inner", FlattenEmptyInner_test)
+	t.Run("Flatten nil inner", FlattenNilInner_test)
+	t.Run("Flatten cycle", FlattenCycle_test)
+	t.Run("Flatten Option", FlattenOption_test)
+	t.Run("MapFlat", MapFlat_test)
+	t.Run("Map does not flatten", MapNoFlatten_test)
+}
+
+func Flatten_test(t *testing.T) {
+	defer shouldNotPanic("optional.Flatten", t)
+
+	o := op.Of(op.Of(op.Of(TEST_INT))).Flatten()
+	if v, ok := o.Get().(int); !ok || v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, o.Get())
+	}
+}
+
+func FlattenNotNested_test(t *testing.T) {
+	defer shouldNotPanic("optional.Flatten", t)
+
+	o := op.Of(TEST_STR).Flatten()
+	if v := o.Get().(string); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+	if op.Empty().Flatten().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func FlattenEmptyInner_test(t *testing.T) {
+	defer shouldNotPanic("optional.Flatten", t)
+
+	o := op.Of(op.Of(op.EmptyBecause("missing"))).Flatten()
+	if o.IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	if o.Reason() != "missing" {
+		t.Errorf("Expected reason `missing`, got `%v`", o.Reason())
+	}
+}
+
+func FlattenNilInner_test(t *testing.T) {
+	defer shouldNotPanic("optional.Flatten", t)
+
+	var inner *op.Optional
+	if op.Of(inner).Flatten().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func FlattenCycle_test(t *testing.T) {
+	defer shouldNotPanic("optional.Flatten", t)
+
+	a, b := op.Empty(), op.Empty()
+	a.OrOptional(op.Of(b))
+	b.OrOptional(op.Of(a))
+	if op.Of(a).Flatten().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func FlattenOption_test(t *testing.T) {
+	defer shouldNotPanic("optional.Flatten", t)
+
+	o := op.Flatten(op.OptionOf(op.OptionOf(TEST_INT)))
+	if v := o.Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.Flatten(op.OptionOf(op.OptionEmpty[int]())).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	if op.Flatten(op.OptionEmpty[op.Option[int]]()).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func MapFlat_test(t *testing.T) {
+	defer shouldNotPanic("optional.MapFlat", t)
+
+	o := op.Of(TEST_INT).MapFlat(func(t op.T) op.T {
+		return op.Of(strconv.Itoa(t.(int)))
+	}).Map(func(t op.T) op.T {
+		return t.(string) + TEST_STR
+	})
+	if v := o.Get().(string); v != TEST_STR+TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR+TEST_STR, v)
+	}
+
+	o = op.Of(TEST_INT).MapFlat(func(t op.T) op.T {
+		return op.Empty()
+	})
+	if o.IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	if op.Empty().MapFlat(func(t op.T) op.T { return t }).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func MapNoFlatten_test(t *testing.T) {
+	defer shouldNotPanic("optional.Map", t)
+
+	o := op.Of(TEST_INT).Map(func(t op.T) op.T {
+		return op.Of(strconv.Itoa(t.(int)))
+	})
+	if _, ok := o.Get().(*op.Optional); !ok {
+		t.Errorf("Expected an *Optional, got `%T`", o.Get())
+	}
+}
@@ -0,0 +1,58 @@
+package optional
+
+// If a value is present, returns the result of applying onPresent to the
+// value, otherwise returns the result produced by onEmpty.
+//
+// Unlike IfPresentOrElse, both branches return a value, so the result can be
+// assigned directly.
+func (o *Optional) Fold(onEmpty func() T, onPresent Mapper) T {
+	if o.present {
+		return onPresent(o.t)
+	}
+	return onEmpty()
+}
+
+// If a value is present, returns the result of applying onPresent to the
+// value, otherwise returns the result produced by onEmpty.
+func Fold[V, R any](o Option[V], onEmpty func() R, onPresent func(V) R) R {
+	if o.present {
+		return onPresent(o.v)
+	}
+	return onEmpty()
+}
+
+// struct MatchPresent is the first stage of a Match. Its only method, Present,
+// takes the arm run when a value is present.
+type MatchPresent[V, R any] struct {
+	o Option[V]
+}
+
+// struct MatchEmpty is the second stage of a Match. Its only method, Empty,
+// takes the arm run when no value is present and returns the result.
+type MatchEmpty[V, R any] struct {
+	o         Option[V]
+	onPresent func(V) R
+}
+
+// Starts a match on the given Option producing a result of type R.
+//
+// A result is only available once both arms have been supplied, so a match
+// missing either arm does not compile:
+//
+//	n := optional.Match[string, int](o).
+//		Present(func(s string) int { return len(s) }).
+//		Empty(func() int { return -1 })
+func Match[V, R any](o Option[V]) MatchPresent[V, R] {
+	return MatchPresent[V, R]{o: o}
+}
+
+// Sets the arm run when a value is present.
+func (m MatchPresent[V, R]) Present(f func(V) R) MatchEmpty[V, R] {
+	return MatchEmpty[V, R]{o: m.o, onPresent: f}
+}
+
+// Sets the arm run when no value is present and returns the result of the
+// match.
+func (m MatchEmpty[V, R]) Empty(f func() R) R {
+	return Fold(m.o, f, m.onPresent)
+}
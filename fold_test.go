@@ -0,0 +1,107 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"strconv"
+	"testing"
+)
+
+func Test_Fold(t *testing.T) {
+	t.Run("Fold", Fold_test)
+	t.Run("Fold empty", FoldEmpty_test)
+	t.Run("Fold Option", FoldOption_test)
+	t.Run("Fold Option empty", FoldOptionEmpty_test)
+}
+
+func Fold_test(t *testing.T) {
+	defer shouldNotPanic("optional.Fold", t)
+
+	v := op.Of(TEST_STR).Fold(func() op.T {
+		t.Error("onEmpty should not be called.")
+		return nil
+	}, func(t op.T) op.T {
+		i, _ := strconv.Atoi(t.(string))
+		return i
+	})
+	if v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func FoldEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.Fold", t)
+
+	v := op.Empty().Fold(func() op.T {
+		return TEST_OTHER
+	}, func(t op.T) op.T {
+		panic("onPresent should not be called.")
+	})
+	if v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+}
+
+func FoldOption_test(t *testing.T) {
+	defer shouldNotPanic("optional.Fold", t)
+
+	n := op.Fold(op.OptionOf(TEST_STR), func() int {
+		return -1
+	}, func(s string) int {
+		return len(s)
+	})
+	if n != len(TEST_STR) {
+		t.Errorf("Expected `%v`, got `%v`", len(TEST_STR), n)
+	}
+}
+
+func FoldOptionEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.Fold", t)
+
+	n := op.Fold(op.OptionEmpty[string](), func() int {
+		return -1
+	}, func(s string) int {
+		return len(s)
+	})
+	if n != -1 {
+		t.Errorf("Expected `%v`, got `%v`", -1, n)
+	}
+}
+
+func Test_Match(t *testing.T) {
+	t.Run("Match", Match_test)
+	t.Run("Match empty", MatchEmpty_test)
+	t.Run("Match from Optional", MatchFromOptional_test)
+}
+
+func Match_test(t *testing.T) {
+	defer shouldNotPanic("optional.Match", t)
+
+	s := op.Match[int, string](op.OptionOf(TEST_INT)).
+		Present(strconv.Itoa).
+		Empty(func() string { return "none" })
+	if s != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, s)
+	}
+}
+
+func MatchEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.Match", t)
+
+	s := op.Match[int, string](op.OptionEmpty[int]()).
+		Present(strconv.Itoa).
+		Empty(func() string { return "none" })
+	if s != "none" {
+		t.Errorf("Expected `%v`, got `%v`", "none", s)
+	}
+}
+
+func MatchFromOptional_test(t *testing.T) {
+	defer shouldNotPanic("optional.Match", t)
+
+	s := op.Match[*S, int](op.OptionFrom[*S](op.Of(&S{TEST_INT}))).
+		Present(func(s *S) int { return s.v }).
+		Empty(func() int { return TEST_OTHER })
+	if s != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, s)
+	}
+}
@@ -1 +1,3 @@
 module github.com/MercuryThePlanet/optional
+
+go 1.21
@@ -0,0 +1,153 @@
+package optional
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// struct Key is a comparable form of an Optional holding a comparable value.
+//
+// Keys of equal values are equal, and all empty Keys are equal, so Keys can be
+// compared with == and used as map keys. An Option of a comparable type is
+// comparable itself. For other values, see Hash.
+type Key struct {
+	t       T
+	present bool
+}
+
+// Returns the Key of the Optional. Panics if the value is not comparable.
+func (o *Optional) Key() Key {
+	if !o.present {
+		return Key{}
+	}
+	if !reflect.ValueOf(o.t).Comparable() {
+		panic(fmt.Sprintf("optional.Key: %T is not comparable. Use Hash instead.", o.t))
+	}
+	return Key{t: o.t, present: true}
+}
+
+// Returns an Optional describing the value of the Key, if present, otherwise
+// returns an empty Optional.
+func (k Key) Optional() *Optional {
+	return &Optional{t: k.t, present: k.present}
+}
+
+// If a value is present, returns true, otherwise false.
+func (k Key) IsPresent() bool {
+	return k.present
+}
+
+// If a value is present, returns the value, otherwise returns nil.
+func (k Key) Get() T {
+	return k.t
+}
+
+// Returns a hash of the Optional with the given seed.
+//
+// Optionals whose values are deeply equal have equal hashes, so Hash can key
+// sets and caches of values of any type, including ones that are not
+// comparable. Pointers are hashed by what they point to; funcs and channels
+// by identity.
+func (o *Optional) Hash(seed maphash.Seed) uint64 {
+	return hash(seed, o.present, reflect.ValueOf(o.t))
+}
+
+// Returns a hash of the Option with the given seed, as Optional.Hash does.
+func (o Option[V]) Hash(seed maphash.Seed) uint64 {
+	return hash(seed, o.present, reflect.ValueOf(&o.v).Elem())
+}
+
+func hash(seed maphash.Seed, present bool, v reflect.Value) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	if present {
+		h.WriteByte(1)
+		writeValue(&h, seed, v, 0)
+	} else {
+		h.WriteByte(0)
+	}
+	return h.Sum64()
+}
+
+// The nesting depth below which values are not hashed, which also bounds
+// cyclic values.
+const maxHashDepth = 32
+
+func writeValue(h *maphash.Hash, seed maphash.Seed, v reflect.Value, depth int) {
+	if !v.IsValid() || depth > maxHashDepth {
+		h.WriteByte(0)
+		return
+	}
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+
+	h.WriteString(v.Type().String())
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(floatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint(floatBits(real(v.Complex())))
+		writeUint(floatBits(imag(v.Complex())))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Slice, reflect.Array:
+		writeUint(uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			writeValue(h, seed, v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		// Entries are hashed separately and summed, so that the order of
+		// iteration does not matter.
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			var e maphash.Hash
+			e.SetSeed(seed)
+			writeValue(&e, seed, iter.Key(), depth+1)
+			writeValue(&e, seed, iter.Value(), depth+1)
+			sum += e.Sum64()
+		}
+		writeUint(uint64(v.Len()))
+		writeUint(sum)
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+		} else {
+			h.WriteByte(1)
+			writeValue(h, seed, v.Elem(), depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			writeValue(h, seed, v.Field(i), depth+1)
+		}
+	default:
+		writeUint(uint64(v.Pointer()))
+	}
+}
+
+// Returns the bits of f, with both zeros mapped to the same bits as they are
+// equal.
+func floatBits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
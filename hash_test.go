@@ -0,0 +1,161 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"hash/maphash"
+	"math"
+	"testing"
+)
+
+func Test_Key(t *testing.T) {
+	t.Run("Key", Key_test)
+	t.Run("Key map", KeyMap_test)
+	t.Run("Key not comparable", KeyNotComparable_test)
+	t.Run("Key not comparable interface field", KeyNotComparableField_test)
+	t.Run("Option comparable", OptionComparable_test)
+}
+
+func Key_test(t *testing.T) {
+	defer shouldNotPanic("optional.Key", t)
+
+	if op.Of(TEST_STR).Key() != op.Of(TEST_STR).Key() {
+		t.Error("Keys of equal values should be equal.")
+	}
+	if op.Of(TEST_STR).Key() == op.Of(TEST_INT).Key() {
+		t.Error("Keys of different values should not be equal.")
+	}
+	if op.Empty().Key() != op.Of(TEST_INT).Filter(func(op.T) bool { return false }).Key() {
+		t.Error("Keys of empty optionals should be equal.")
+	}
+	if v := op.Of(TEST_INT).Key().Optional().Get().(int); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if k := op.Empty().Key(); k.IsPresent() || k.Get() != nil || k.Optional().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func KeyMap_test(t *testing.T) {
+	defer shouldNotPanic("optional.Key", t)
+
+	seen := map[op.Key]int{}
+	for _, o := range []*op.Optional{op.Of(TEST_STR), op.Empty(), op.Of(TEST_STR), op.OfNilable(nil)} {
+		seen[o.Key()]++
+	}
+	if len(seen) != 2 || seen[op.Of(TEST_STR).Key()] != 2 || seen[op.Key{}] != 2 {
+		t.Errorf("Unexpected set `%v`", seen)
+	}
+}
+
+func KeyNotComparable_test(t *testing.T) {
+	defer shouldPanic("optional.Key with a slice", t)
+
+	op.Of([]int{1}).Key()
+	t.Fatal("this code should not be reachable.")
+}
+
+func KeyNotComparableField_test(t *testing.T) {
+	defer shouldPanic("optional.Key with a slice in an interface field", t)
+
+	type W struct{ X interface{} }
+	op.Of(W{X: []int{1}}).Key()
+	t.Fatal("this code should not be reachable.")
+}
+
+func OptionComparable_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option", t)
+
+	type row struct {
+		ID   op.Option[int]
+		Name op.Option[string]
+	}
+	a := row{op.OptionOf(1), op.OptionEmpty[string]()}
+	b := row{op.OptionOf(1), op.OptionOf("x").Filter(func(string) bool { return false })}
+	if a != b {
+		t.Error("Rows of equal options should be equal.")
+	}
+	cache := map[op.Option[int]]string{op.OptionOf(1): "one", op.OptionEmpty[int](): "none"}
+	if cache[op.OptionOf(1)] != "one" || cache[op.Option[int]{}] != "none" {
+		t.Errorf("Unexpected cache `%v`", cache)
+	}
+}
+
+func Test_Hash(t *testing.T) {
+	t.Run("Hash equal values", HashEqual_test)
+	t.Run("Hash different values", HashDifferent_test)
+	t.Run("Hash seed", HashSeed_test)
+	t.Run("Option Hash", OptionHash_test)
+}
+
+type tree struct {
+	Label    string
+	Children []*tree
+	Attrs    map[string][]int
+}
+
+func sampleTree() *tree {
+	return &tree{"root", []*tree{{Label: "leaf"}}, map[string][]int{"a": {1}, "b": {2, 3}, "c": nil}}
+}
+
+func HashEqual_test(t *testing.T) {
+	defer shouldNotPanic("optional.Hash", t)
+
+	seed := maphash.MakeSeed()
+	for name, pair := range map[string][2]*op.Optional{
+		"empty":  {op.Empty(), op.OfNilable(nil)},
+		"int":    {op.Of(TEST_INT), op.Of(TEST_INT)},
+		"slice":  {op.Of([]string{"a", "b"}), op.Of([]string{"a", "b"})},
+		"tree":   {op.Of(sampleTree()), op.Of(sampleTree())},
+		"zeros":  {op.Of(0.0), op.Of(math.Copysign(0, -1))},
+		"struct": {op.Of(*sampleTree()), op.Of(*sampleTree())},
+	} {
+		if pair[0].Hash(seed) != pair[1].Hash(seed) {
+			t.Errorf("%s: hashes of equal optionals should be equal", name)
+		}
+	}
+}
+
+func HashDifferent_test(t *testing.T) {
+	defer shouldNotPanic("optional.Hash", t)
+
+	seed := maphash.MakeSeed()
+	other := sampleTree()
+	other.Attrs["b"][1] = 4
+	for name, pair := range map[string][2]*op.Optional{
+		"empty":  {op.Empty(), op.Of(0)},
+		"int":    {op.Of(TEST_INT), op.Of(TEST_OTHER)},
+		"type":   {op.Of(1), op.Of(int64(1))},
+		"slice":  {op.Of([]string{"ab"}), op.Of([]string{"a", "b"})},
+		"tree":   {op.Of(sampleTree()), op.Of(other)},
+		"nil":    {op.Of([]*tree{nil}), op.Of([]*tree{{}})},
+		"string": {op.Of(""), op.Of(0)},
+	} {
+		if pair[0].Hash(seed) == pair[1].Hash(seed) {
+			t.Errorf("%s: hashes of different optionals should differ", name)
+		}
+	}
+}
+
+func HashSeed_test(t *testing.T) {
+	defer shouldNotPanic("optional.Hash", t)
+
+	o := op.Of(TEST_STR)
+	if o.Hash(maphash.MakeSeed()) == o.Hash(maphash.MakeSeed()) {
+		t.Error("Hashes with different seeds should differ.")
+	}
+}
+
+func OptionHash_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option.Hash", t)
+
+	seed := maphash.MakeSeed()
+	if op.OptionOf([]int{1, 2}).Hash(seed) != op.OptionOf([]int{1, 2}).Hash(seed) {
+		t.Error("Hashes of equal options should be equal.")
+	}
+	if op.OptionOf([]int{1, 2}).Hash(seed) == op.OptionOf([]int{2, 1}).Hash(seed) {
+		t.Error("Hashes of different options should differ.")
+	}
+	if op.OptionEmpty[[]int]().Hash(seed) != op.Empty().Hash(seed) {
+		t.Error("Hashes of empty options should be equal.")
+	}
+}
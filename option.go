@@ -0,0 +1,169 @@
+package optional
+
+import "reflect"
+
+// struct Option is a typed optional container.
+//
+// Unlike Optional, an Option is a value: combinators return a new Option
+// rather than mutating the receiver, and the zero Option is empty. Its
+// payload is stored unboxed, so constructing and chaining Options of scalar
+// types does not allocate.
+type Option[V any] struct {
+	v       V
+	present bool
+}
+
+// Returns an empty Option instance.
+func OptionEmpty[V any]() Option[V] {
+	return Option[V]{}
+}
+
+// Returns an Option describing the given non-nil value.
+func OptionOf[V any](v V) Option[V] {
+	if isNil(v) {
+		panic("optional.OptionOf takes a non-nil value. Use OptionOfNilable for potentially nil values.")
+	}
+	return Option[V]{v: v, present: true}
+}
+
+// Returns an Option describing the given value, if non-nil, otherwise returns
+// an empty Option.
+func OptionOfNilable[V any](v V) Option[V] {
+	if isNil(v) {
+		return Option[V]{}
+	}
+	return Option[V]{v: v, present: true}
+}
+
+// If the error is nil, returns an Option describing the given value, if
+// non-nil, otherwise returns an empty Option.
+func OptionOfErrorable[V any](v V, err error) Option[V] {
+	if err != nil {
+		return Option[V]{}
+	}
+	return OptionOfNilable(v)
+}
+
+// Returns an Option describing the value of the Optional, if a value is
+// present and has type V, otherwise returns an empty Option.
+func OptionFrom[V any](o *Optional) Option[V] {
+	v, ok := o.Get().(V)
+	if !ok {
+		return Option[V]{}
+	}
+	return Option[V]{v: v, present: true}
+}
+
+// If a value is present, returns an Option describing the result of applying
+// the given mapping function to the value, if non-nil, otherwise returns an
+// empty Option.
+func Map[V, U any](o Option[V], f func(V) U) Option[U] {
+	if !o.present {
+		return Option[U]{}
+	}
+	return OptionOfNilable(f(o.v))
+}
+
+// If a value is present, returns the result of applying the given
+// Option-bearing mapping function to the value, otherwise returns an empty
+// Option.
+func FlatMap[V, U any](o Option[V], f func(V) Option[U]) Option[U] {
+	if !o.present {
+		return Option[U]{}
+	}
+	return f(o.v)
+}
+
+// Returns an Optional describing the value, if present, otherwise returns an
+// empty Optional.
+func (o Option[V]) Optional() *Optional {
+	if !o.present {
+		return Empty()
+	}
+	return Of(o.v)
+}
+
+// If a value is present and matches the given predicate, returns the Option,
+// otherwise returns an empty Option.
+func (o Option[V]) Filter(f func(V) bool) Option[V] {
+	if o.present && f(o.v) {
+		return o
+	}
+	return Option[V]{}
+}
+
+// If a value is present, returns the Option, otherwise returns an Option
+// describing the value produced by the supplying function, if non-nil.
+func (o Option[V]) Or(f func() V) Option[V] {
+	if o.present {
+		return o
+	}
+	return OptionOfNilable(f())
+}
+
+// If a value is present, returns the value, otherwise returns the zero value
+// of V.
+func (o Option[V]) Get() V {
+	return o.v
+}
+
+// If a value is present, performs the given action with the value, otherwise
+// does nothing.
+func (o Option[V]) IfPresent(f func(V)) {
+	if o.present {
+		f(o.v)
+	}
+}
+
+// If a value is present, performs the given action with the value, otherwise
+// performs the given runnable action.
+func (o Option[V]) IfPresentOrElse(f func(V), other Runnable) {
+	if o.present {
+		f(o.v)
+	} else {
+		other()
+	}
+}
+
+// If a value is present, returns true, otherwise false.
+func (o Option[V]) IsPresent() bool {
+	return o.present
+}
+
+// If a value is present, returns the value, otherwise returns other.
+func (o Option[V]) OrElse(other V) V {
+	if o.present {
+		return o.v
+	}
+	return other
+}
+
+// If a value is present, returns the value, otherwise returns the result
+// produced by the supplying function.
+func (o Option[V]) OrElseGet(f func() V) V {
+	if o.present {
+		return o.v
+	}
+	return f()
+}
+
+// If a value is present, returns the value, otherwise panics.
+func (o Option[V]) OrElsePanic(p string) V {
+	if o.present {
+		return o.v
+	}
+	panic(p)
+}
+
+// Reports whether v is nil. Only values of nilable kinds are inspected, so
+// scalar values are never boxed.
+func isNil[V any](v V) bool {
+	switch reflect.TypeOf((*V)(nil)).Elem().Kind() {
+	case reflect.Interface:
+		return any(v) == nil
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func,
+		reflect.UnsafePointer:
+		return reflect.ValueOf(&v).Elem().IsNil()
+	}
+	return false
+}
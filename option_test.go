@@ -0,0 +1,223 @@
+package optional_test
+
+import (
+	"errors"
+	op "github.com/MercuryThePlanet/optional"
+	"strconv"
+	"testing"
+)
+
+func Test_Option(t *testing.T) {
+	t.Run("OptionEmpty", OptionEmpty_test)
+	t.Run("OptionOf", OptionOf_test)
+	t.Run("OptionOf nil", OptionOfNil_test)
+	t.Run("OptionOfNilable", OptionOfNilable_test)
+	t.Run("OptionOfErrorable", OptionOfErrorable_test)
+	t.Run("OptionFrom", OptionFrom_test)
+	t.Run("Option Optional", OptionOptional_test)
+}
+
+func OptionEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionEmpty", t)
+
+	var zero op.Option[int]
+	if op.OptionEmpty[int]().IsPresent() || zero.IsPresent() {
+		t.Error("Empty option should have no value")
+	}
+	if op.OptionEmpty[int]() != zero {
+		t.Error("Empty option should be the zero Option.")
+	}
+}
+
+func OptionOf_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionOf", t)
+
+	o := op.OptionOf(TEST_STR)
+	if !o.IsPresent() {
+		t.Error("Value should be present.")
+	} else if v := o.Get(); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+}
+
+func OptionOfNil_test(t *testing.T) {
+	defer shouldPanic("optional.OptionOf with nil value", t)
+
+	op.OptionOf[*S](nil)
+	t.Fatal("this code should not be reachable.")
+}
+
+func OptionOfNilable_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionOfNilable", t)
+
+	if op.OptionOfNilable[*S](nil).IsPresent() {
+		t.Error("Nil pointer should be empty.")
+	}
+	if op.OptionOfNilable[error](nil).IsPresent() {
+		t.Error("Nil interface should be empty.")
+	}
+	if op.OptionOfNilable[[]int](nil).IsPresent() {
+		t.Error("Nil slice should be empty.")
+	}
+	if !op.OptionOfNilable(0).IsPresent() {
+		t.Error("Zero int should be present.")
+	}
+}
+
+func OptionOfErrorable_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionOfErrorable", t)
+
+	if v := op.OptionOfErrorable(strconv.Atoi(TEST_STR)).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.OptionOfErrorable(TEST_INT, errors.New("Test")).IsPresent() {
+		t.Error("Option with an error should be empty.")
+	}
+}
+
+func OptionFrom_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionFrom", t)
+
+	if v := op.OptionFrom[int](op.Of(TEST_INT)).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.OptionFrom[string](op.Of(TEST_INT)).IsPresent() {
+		t.Error("Option of a different type should be empty.")
+	}
+	if op.OptionFrom[int](op.Empty()).IsPresent() {
+		t.Error("Option from an empty Optional should be empty.")
+	}
+}
+
+func OptionOptional_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option.Optional", t)
+
+	if v := op.OptionOf(TEST_INT).Optional().Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.OptionEmpty[int]().Optional().IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+func Test_OptionMap(t *testing.T) {
+	t.Run("Map", OptionMap_test)
+	t.Run("Map returns nil", OptionMapNil_test)
+	t.Run("Map empty option", OptionMapEmpty_test)
+	t.Run("FlatMap", OptionFlatMap_test)
+}
+
+func OptionMap_test(t *testing.T) {
+	defer shouldNotPanic("optional.Map", t)
+
+	o := op.Map(op.OptionOf(TEST_STR), func(v string) int {
+		i, _ := strconv.Atoi(v)
+		return i
+	})
+	if v := o.Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func OptionMapNil_test(t *testing.T) {
+	defer shouldNotPanic("optional.Map", t)
+
+	o := op.Map(op.OptionOf(TEST_STR), func(v string) *S { return nil })
+	if o.IsPresent() {
+		t.Error("Option should be empty.")
+	}
+}
+
+func OptionMapEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.Map", t)
+
+	op.Map(op.OptionEmpty[string](), func(v string) int {
+		t.Fatal("Map on empty option should not run")
+		return 0
+	})
+}
+
+func OptionFlatMap_test(t *testing.T) {
+	defer shouldNotPanic("optional.FlatMap", t)
+
+	parse := func(v string) op.Option[int] {
+		return op.OptionOfErrorable(strconv.Atoi(v))
+	}
+	if v := op.FlatMap(op.OptionOf(TEST_STR), parse).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.FlatMap(op.OptionOf("abc"), parse).IsPresent() {
+		t.Error("Option should be empty.")
+	}
+}
+
+func Test_OptionMethods(t *testing.T) {
+	t.Run("Filter", OptionFilter_test)
+	t.Run("Or", OptionOr_test)
+	t.Run("IfPresent", OptionIfPresent_test)
+	t.Run("OrElse", OptionOrElse_test)
+	t.Run("OrElsePanic", OptionOrElsePanic_test)
+}
+
+func OptionFilter_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option.Filter", t)
+
+	o := op.OptionOf(TEST_INT)
+	if o.Filter(func(v int) bool { return v > TEST_OTHER }).IsPresent() {
+		t.Error("Filtered option should be empty.")
+	}
+	if !o.IsPresent() {
+		t.Error("Filter should not mutate its receiver.")
+	}
+	if !o.Filter(func(v int) bool { return v == TEST_INT }).IsPresent() {
+		t.Error("Value should be present.")
+	}
+}
+
+func OptionOr_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option.Or", t)
+
+	other := func() int { return TEST_OTHER }
+	if v := op.OptionOf(TEST_INT).Or(other).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if v := op.OptionEmpty[int]().Or(other).Get(); v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+}
+
+func OptionIfPresent_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option.IfPresent", t)
+
+	ok := false
+	op.OptionOf(TEST_INT).IfPresent(func(v int) { ok = v == TEST_INT })
+	op.OptionEmpty[int]().IfPresent(func(v int) {
+		t.Error("IfPresent was reached when it should not have been.")
+	})
+	op.OptionEmpty[int]().IfPresentOrElse(func(v int) {
+		t.Error("IfPresentOrElse first was reached when it should not have been.")
+	}, func() {})
+	if !ok {
+		t.Error("IfPresent was not reached when it should have been.")
+	}
+}
+
+func OptionOrElse_test(t *testing.T) {
+	defer shouldNotPanic("optional.Option.OrElse", t)
+
+	if v := op.OptionEmpty[int]().OrElse(TEST_OTHER); v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+	if v := op.OptionOf(TEST_INT).OrElseGet(func() int {
+		return TEST_OTHER
+	}); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func OptionOrElsePanic_test(t *testing.T) {
+	defer shouldPanic("optional.Option.OrElsePanic", t)
+
+	op.OptionEmpty[int]().OrElsePanic(TEST_PANIC)
+	t.Fatal("This code should be unreachable.")
+}
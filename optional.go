@@ -5,10 +5,16 @@
 // value is nil the code will not be executed and enter a panic.
 package optional
 
+import "reflect"
+
 // struct Optional is the container struct.
 type Optional struct {
 	t       T
 	present bool
+	reason  string
+	traced  bool
+	trace   []Step
+	typ     reflect.Type
 }
 
 // Interface contains one method, Cmpr, which takes an empty interface
@@ -54,6 +60,9 @@ func Empty() *Optional {
 func (o *Optional) set(t T, present bool) *Optional {
 	o.t = t
 	o.present = present
+	if present {
+		o.reason = ""
+	}
 	return o
 }
 
@@ -79,9 +88,9 @@ func (o *Optional) Equals(t T) bool {
 // Optional.
 func (o *Optional) Filter(f Predicate) *Optional {
 	if f(o.t) {
-		return o
+		return o.record("Filter")
 	} else {
-		return o.set(nil, false)
+		return o.set(nil, false).record("Filter")
 	}
 }
 
@@ -112,10 +121,10 @@ func OfErrorable(t T, err error) *Optional {
 // returns an Optional produced by the supplying function.
 func (o *Optional) Or(f Supplier, ts ...T) *Optional {
 	if o.present {
-		return o
+		return o.record("Or")
 	} else {
 		t := f(ts)
-		return o.set(t, t != nil)
+		return o.set(t, t != nil).record("Or")
 	}
 }
 
@@ -156,9 +165,9 @@ func (o *Optional) IsPresent() bool {
 func (o *Optional) Map(f Mapper) *Optional {
 	if o.present {
 		mapped_t := f(o.t)
-		return o.set(mapped_t, mapped_t != nil)
+		return o.set(mapped_t, mapped_t != nil).record("Map")
 	}
-	return o.set(nil, false)
+	return o.set(nil, false).record("Map")
 }
 
 // If a value is present, returns the result of applying the given
@@ -168,10 +177,15 @@ func (o *Optional) FlatMap(f Mapper) T {
 	if o.present {
 		mapped_t := f(o.t)
 		if mapped_t != nil {
+			o.record("FlatMap")
+			if next, ok := mapped_t.(*Optional); ok && o.traced {
+				next.traced = true
+				next.trace = append(append([]Step(nil), o.trace...), next.trace...)
+			}
 			return mapped_t
 		}
 	}
-	return o.set(nil, false)
+	return o.set(nil, false).record("FlatMap")
 }
 
 // If a value is present, returns the value, otherwise returns other.
@@ -194,9 +208,15 @@ func (o *Optional) OrElseGet(f Supplier, ts ...T) T {
 }
 
 // If a value is present, returns the value, otherwise panics.
+//
+// If the Optional carries a reason or a trace, they are appended to the panic
+// message.
 func (o *Optional) OrElsePanic(p string) T {
 	if o.present {
 		return o.t
 	}
+	if o.reason != "" || o.traced {
+		panic(o.describe(p))
+	}
 	panic(p)
 }
@@ -0,0 +1,16 @@
+// Command optionalcheck reports unsafe uses of the optional package.
+//
+// It may be run on its own or by go vet:
+//
+//	go install github.com/MercuryThePlanet/optional/optionalcheck/cmd/optionalcheck@latest
+//	go vet -vettool=$(which optionalcheck) ./...
+package main
+
+import (
+	"github.com/MercuryThePlanet/optional/optionalcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(optionalcheck.Analyzer)
+}
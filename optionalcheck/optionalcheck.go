@@ -0,0 +1,390 @@
+// package optionalcheck defines an Analyzer that reports unsafe uses of the
+// optional package.
+//
+// It reports:
+//
+//   - Get followed by a type assertion without a prior IsPresent check, which
+//     panics when the Optional is empty.
+//   - Of called with a value that may be nil, which panics. OfNilable should
+//     be used instead.
+//   - Map, Filter, Or and FlatMap calls whose result is discarded. These
+//     combinators mutate their receiver, so the receiver does not keep its
+//     old value.
+//   - Map, Filter and Or results assigned to another variable. These
+//     combinators return their receiver, so both names hold one Optional.
+//   - Unchecked *Optional type assertions on FlatMap results, which panic
+//     when the mapper returns a value that is not an Optional.
+package optionalcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const optionalPath = "github.com/MercuryThePlanet/optional"
+
+const doc = `report unsafe uses of the optional package
+
+The optionalcheck analyzer reports type assertions on Get without an IsPresent
+check, Of calls with possibly nil values, discarded results of the mutating
+combinators Map, Filter, Or and FlatMap, aliased results of Map, Filter and Or,
+which return their receiver, and unchecked *Optional assertions on FlatMap
+results.`
+
+// Analyzer reports unsafe uses of the optional package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "optionalcheck",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// The combinators that mutate their receiver.
+var mutating = map[string]bool{
+	"Map":     true,
+	"MapFlat": true,
+	"Filter":  true,
+	"Or":      true,
+	"FlatMap": true,
+}
+
+// The combinators that return their receiver. FlatMap returns the mapper's
+// result when a value is present.
+var returnsReceiver = map[string]bool{
+	"Map":     true,
+	"MapFlat": true,
+	"Filter":  true,
+	"Or":      true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{
+		(*ast.TypeAssertExpr)(nil),
+		(*ast.CallExpr)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+	}
+	insp.WithStack(filter, func(n ast.Node, push bool,
+		stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.TypeAssertExpr:
+			checkAssert(pass, n, stack)
+		case *ast.CallExpr:
+			if isFunc(pass, n, "Of") && len(n.Args) == 1 {
+				checkOf(pass, n, stack)
+			}
+		case *ast.ExprStmt:
+			call, ok := unparen(n.X).(*ast.CallExpr)
+			if name := methodName(pass, call); ok && mutating[name] {
+				pass.Reportf(call.Pos(),
+					"result of %s is discarded; %s mutates its receiver, "+
+						"assign the result or use IfPresent",
+					types.ExprString(call.Fun), name)
+			}
+		case *ast.AssignStmt:
+			checkAlias(pass, n)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// Reports single-value type assertions on Get without an IsPresent check
+// and on FlatMap results.
+func checkAssert(pass *analysis.Pass, assert *ast.TypeAssertExpr,
+	stack []ast.Node) {
+	if assert.Type == nil || isCommaOk(assert, stack) {
+		return
+	}
+	call, ok := unparen(assert.X).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	switch methodName(pass, call) {
+	case "Get":
+		recv := call.Fun.(*ast.SelectorExpr).X
+		if isFunc(pass, unparen(recv), "Of") {
+			return
+		}
+		name := types.ExprString(recv)
+		if guarded(stack, func(cond ast.Expr) bool {
+			return mentions(cond, name, "IsPresent", false)
+		}, func(cond ast.Expr) bool {
+			return mentions(cond, name, "IsPresent", true)
+		}) {
+			return
+		}
+		pass.Reportf(assert.Pos(),
+			"type assertion on %s.Get() without an IsPresent check "+
+				"panics if the Optional is empty", name)
+	case "FlatMap":
+		if isOptionalPtr(pass.TypesInfo.TypeOf(assert.Type)) {
+			pass.Reportf(assert.Pos(),
+				"unchecked *Optional assertion on a FlatMap result panics "+
+					"if the mapper returns a value that is not an Optional")
+		}
+	}
+}
+
+// Reports Of calls whose argument may be nil.
+func checkOf(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	arg := unparen(call.Args[0])
+	tv := pass.TypesInfo.Types[arg]
+	if tv.IsNil() {
+		pass.Reportf(call.Pos(), "optional.Of(nil) always panics; use Empty")
+		return
+	}
+	if !nilable(tv.Type) || nonNil(pass, arg) {
+		return
+	}
+	name := types.ExprString(arg)
+	if guarded(stack, func(cond ast.Expr) bool {
+		return comparesNil(cond, name, token.NEQ)
+	}, func(cond ast.Expr) bool {
+		return comparesNil(cond, name, token.EQL)
+	}) {
+		return
+	}
+	pass.Reportf(call.Pos(),
+		"optional.Of panics if %s is nil; use OfNilable or check for nil first",
+		name)
+}
+
+// Reports assignments of a combinator's result to a variable other than its
+// receiver, when the combinator returns its receiver.
+func checkAlias(pass *analysis.Pass, assign *ast.AssignStmt) {
+	if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return
+	}
+	call, ok := unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	name := methodName(pass, call)
+	if !returnsReceiver[name] {
+		return
+	}
+	recv, ok := unparen(call.Fun.(*ast.SelectorExpr).X).(*ast.Ident)
+	if !ok || pass.TypesInfo.ObjectOf(recv) == nil {
+		return
+	}
+	if lhs, ok := assign.Lhs[0].(*ast.Ident); ok &&
+		(lhs.Name == "_" || pass.TypesInfo.ObjectOf(lhs) ==
+			pass.TypesInfo.ObjectOf(recv)) {
+		return
+	}
+	pass.Reportf(call.Pos(),
+		"%s mutates its receiver, so %s and %s are the same Optional",
+		types.ExprString(call.Fun), recv.Name,
+		types.ExprString(assign.Lhs[0]))
+}
+
+// Reports whether the innermost node of the stack is within the body of an
+// if statement or the right operand of an && whose condition satisfies
+// inBody, or follows an if statement in the same or an enclosing block whose
+// condition satisfies exits and whose body always leaves the block.
+func guarded(stack []ast.Node, inBody, exits func(ast.Expr) bool) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch s := stack[i].(type) {
+		case *ast.IfStmt:
+			if stack[i+1] == s.Body && inBody(s.Cond) {
+				return true
+			}
+		case *ast.BinaryExpr:
+			if s.Op == token.LAND && stack[i+1] == s.Y && inBody(s.X) {
+				return true
+			}
+		case *ast.BlockStmt:
+			for _, stmt := range s.List {
+				if stmt == stack[i+1] {
+					break
+				}
+				if is, ok := stmt.(*ast.IfStmt); ok && exits(is.Cond) &&
+					terminates(is.Body) {
+					return true
+				}
+			}
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		}
+	}
+	return false
+}
+
+// Reports whether the block ends with a return, branch or panic.
+func terminates(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+	switch s := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		if call, ok := s.X.(*ast.CallExpr); ok {
+			if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "panic" {
+				return true
+			}
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
+				switch sel.Sel.Name {
+				case "Fatal", "Fatalf", "FailNow", "Exit", "Skip", "Skipf":
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Reports whether cond contains a call recv.method(), negated if negated is
+// set.
+func mentions(cond ast.Expr, recv, method string, negated bool) bool {
+	found := false
+	ast.Inspect(cond, func(n ast.Node) bool {
+		expr, ok := n.(ast.Expr)
+		if !ok || found {
+			return !found
+		}
+		if negated {
+			u, ok := expr.(*ast.UnaryExpr)
+			if !ok || u.Op != token.NOT {
+				return true
+			}
+			expr = unparen(u.X)
+		}
+		if call, ok := expr.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok &&
+				sel.Sel.Name == method && types.ExprString(sel.X) == recv {
+				found = true
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// Reports whether cond contains the comparison "name op nil".
+func comparesNil(cond ast.Expr, name string, op token.Token) bool {
+	found := false
+	ast.Inspect(cond, func(n ast.Node) bool {
+		b, ok := n.(*ast.BinaryExpr)
+		if !ok || b.Op != op {
+			return !found
+		}
+		for _, pair := range [][2]ast.Expr{{b.X, b.Y}, {b.Y, b.X}} {
+			if id, ok := pair[1].(*ast.Ident); ok && id.Name == "nil" &&
+				types.ExprString(pair[0]) == name {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// Reports whether the type assertion is the value of a two-value assignment.
+func isCommaOk(assert *ast.TypeAssertExpr, stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch s := stack[i].(type) {
+		case *ast.ParenExpr:
+			continue
+		case *ast.AssignStmt:
+			return len(s.Lhs) == 2 && len(s.Rhs) == 1
+		case *ast.ValueSpec:
+			return len(s.Names) == 2 && len(s.Values) == 1
+		}
+		return false
+	}
+	return false
+}
+
+// Reports whether the expression is never nil: the address of a composite
+// literal, a composite literal, a function literal or a new or make call.
+func nonNil(pass *analysis.Pass, expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		_, ok := unparen(e.X).(*ast.CompositeLit)
+		return e.Op == token.AND && ok
+	case *ast.CompositeLit, *ast.FuncLit:
+		return true
+	case *ast.CallExpr:
+		if id, ok := unparen(e.Fun).(*ast.Ident); ok {
+			b, ok := pass.TypesInfo.Uses[id].(*types.Builtin)
+			return ok && (b.Name() == "new" || b.Name() == "make")
+		}
+	}
+	return false
+}
+
+func nilable(t types.Type) bool {
+	if t == nil {
+		return false
+	}
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Map, *types.Slice,
+		*types.Chan, *types.Signature:
+		return true
+	}
+	return false
+}
+
+// Reports whether the call is to the optional package's function name.
+func isFunc(pass *analysis.Pass, expr ast.Expr, name string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && fn.Name() == name && fn.Pkg() != nil &&
+		fn.Pkg().Path() == optionalPath &&
+		fn.Type().(*types.Signature).Recv() == nil
+}
+
+// Returns the name of the *Optional method called, or the empty string if
+// the call is not to an *Optional method.
+func methodName(pass *analysis.Pass, call *ast.CallExpr) string {
+	if call == nil {
+		return ""
+	}
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return ""
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return ""
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil || !isOptionalPtr(recv.Type()) {
+		return ""
+	}
+	return fn.Name()
+}
+
+func isOptionalPtr(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	return ok && named.Obj().Name() == "Optional" && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == optionalPath
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
@@ -0,0 +1,12 @@
+package optionalcheck_test
+
+import (
+	"testing"
+
+	"github.com/MercuryThePlanet/optional/optionalcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), optionalcheck.Analyzer, "a")
+}
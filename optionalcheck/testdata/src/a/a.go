@@ -0,0 +1,106 @@
+package a
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+)
+
+type user struct{ name string }
+
+func get(o *op.Optional) {
+	_ = o.Get().(string) // want `type assertion on o.Get\(\) without an IsPresent check panics if the Optional is empty`
+
+	_ = op.OfNilable(nil).Get().(int) // want `type assertion on op.OfNilable\(nil\).Get\(\) without an IsPresent check`
+
+	_ = (o.Get()).(string) // want `type assertion on o.Get\(\) without an IsPresent check`
+
+	if o.IsPresent() {
+		_ = o.Get().(string)
+	} else {
+		_ = o.Get().(string) // want `type assertion on o.Get\(\) without an IsPresent check`
+	}
+
+	if o.IsPresent() && o.Get().(string) != "" {
+		_ = o.Get().(string)
+	}
+
+	s, ok := o.Get().(string)
+	_, _ = s, ok
+
+	var t, ok2 = o.Get().(string)
+	_, _ = t, ok2
+
+	switch o.Get().(type) {
+	case string:
+	}
+
+	_ = op.Of(1).Get().(int)
+
+	func() {
+		if !o.IsPresent() {
+			return
+		}
+		_ = o.Get().(string)
+		for {
+			_ = o.Get().(string)
+		}
+	}()
+}
+
+func earlyPanic(o *op.Optional) string {
+	if !o.IsPresent() {
+		panic("empty")
+	}
+	return o.Get().(string)
+}
+
+func of(u *user, v interface{}, err error, n int, s []int) {
+	op.Of(nil) // want `optional.Of\(nil\) always panics; use Empty`
+
+	_ = op.Of(u)   // want `optional.Of panics if u is nil; use OfNilable or check for nil first`
+	_ = op.Of(v)   // want `optional.Of panics if v is nil`
+	_ = op.Of(err) // want `optional.Of panics if err is nil`
+	_ = op.Of(s)   // want `optional.Of panics if s is nil`
+
+	_ = op.Of(n)
+	_ = op.Of("str")
+	_ = op.Of(&user{})
+	_ = op.Of(new(user))
+	_ = op.Of(make([]int, 1))
+	_ = op.Of([]int{1})
+	_ = op.Of(func() {})
+
+	if u != nil {
+		_ = op.Of(u)
+	}
+	if v == nil {
+		return
+	}
+	_ = op.Of(v)
+}
+
+func mutate(o *op.Optional, f op.Mapper, p op.Predicate) {
+	o.Map(f)                            // want `result of o.Map is discarded; Map mutates its receiver, assign the result or use IfPresent`
+	o.Filter(p)                         // want `result of o.Filter is discarded`
+	o.Or(func(op.Ts) op.T { return 1 }) // want `result of o.Or is discarded`
+	o.FlatMap(f)                        // want `result of o.FlatMap is discarded`
+
+	doubled := o.Map(f) // want `o.Map mutates its receiver, so o and doubled are the same Optional`
+	_ = doubled
+	r := o.FlatMap(f)
+	_ = r
+
+	o = o.Map(f)
+	_ = o.Filter(p)
+	other := op.Of(1).Map(f)
+	_ = other
+	o.Get()
+}
+
+func flatMap(o *op.Optional, f op.Mapper) {
+	_ = o.FlatMap(f).(*op.Optional) // want `unchecked \*Optional assertion on a FlatMap result panics if the mapper returns a value that is not an Optional`
+
+	r, ok := o.FlatMap(f).(*op.Optional)
+	_, _ = r, ok
+
+	_ = o.FlatMap(f).(int)
+}
@@ -0,0 +1,36 @@
+// Package optional is a minimal stub of the optional package for the
+// analyzer tests.
+package optional
+
+type Optional struct {
+	t       T
+	present bool
+}
+
+type T = interface{}
+
+type Ts = []T
+
+type (
+	Predicate func(T) bool
+	Supplier  func(Ts) T
+	Mapper    func(T) T
+)
+
+func Empty() *Optional { return &Optional{} }
+
+func Of(t T) *Optional {
+	if t == nil {
+		panic("nil")
+	}
+	return &Optional{t, true}
+}
+
+func OfNilable(t T) *Optional { return &Optional{t, t != nil} }
+
+func (o *Optional) Filter(f Predicate) *Optional     { return o }
+func (o *Optional) Or(f Supplier, ts ...T) *Optional { return o }
+func (o *Optional) Map(f Mapper) *Optional           { return o }
+func (o *Optional) FlatMap(f Mapper) T               { return o }
+func (o *Optional) Get() T                           { return o.t }
+func (o *Optional) IsPresent() bool                  { return o.present }
@@ -0,0 +1,140 @@
+package optionaltest
+
+import (
+	"encoding/binary"
+	op "github.com/MercuryThePlanet/optional"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Returns an Optional derived from fuzz input.
+//
+// The low bit of the first byte selects whether the Optional is present, the
+// next bits whether it holds an int, a string, a float64 or a bool, and the
+// remaining bytes make up the value. Empty input gives an empty Optional.
+func FuzzOptional(data []byte) *op.Optional {
+	if len(data) == 0 || data[0]&1 == 0 {
+		return op.Empty()
+	}
+	var t reflect.Type
+	switch data[0] >> 1 % 4 {
+	case 0:
+		t = reflect.TypeOf(0)
+	case 1:
+		t = reflect.TypeOf("")
+	case 2:
+		t = reflect.TypeOf(0.0)
+	default:
+		t = reflect.TypeOf(false)
+	}
+	v := reflect.New(t).Elem()
+	fill(v, &reader{data[1:]}, 0)
+	return op.Of(v.Interface())
+}
+
+// Returns an Option derived from fuzz input.
+//
+// The low bit of the first byte selects whether the Option is present and
+// the remaining bytes make up the value. Numbers are read little-endian,
+// strings, slices and maps are prefixed by a length byte, and pointers by a
+// byte selecting whether they are nil. Strings are valid UTF-8 and floats are
+// finite, so derived values survive encoding round trips. Interfaces, funcs,
+// channels and unexported struct fields are left zero.
+func FuzzOption[V any](data []byte) op.Option[V] {
+	if len(data) == 0 || data[0]&1 == 0 {
+		return op.OptionEmpty[V]()
+	}
+	var v V
+	fill(reflect.ValueOf(&v).Elem(), &reader{data[1:]}, 0)
+	return op.OptionOfNilable(v)
+}
+
+// The nesting depth below which fill leaves values zero.
+const maxFillDepth = 8
+
+// The largest string, slice or map fill derives.
+const maxFillLen = 32
+
+type reader struct {
+	data []byte
+}
+
+// Returns the next n bytes, padded with zeros once the input runs out.
+func (r *reader) next(n int) []byte {
+	b := make([]byte, n)
+	r.data = r.data[copy(b, r.data):]
+	return b
+}
+
+func (r *reader) uint64(size uintptr) uint64 {
+	b := make([]byte, 8)
+	copy(b, r.next(int(size)))
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (r *reader) len() int {
+	return int(r.next(1)[0]) % (maxFillLen + 1)
+}
+
+func fill(v reflect.Value, r *reader, depth int) {
+	if depth > maxFillDepth || !v.CanSet() {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.next(1)[0]&1 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.uint64(v.Type().Size())))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		v.SetUint(r.uint64(v.Type().Size()))
+	case reflect.Float32:
+		f := float64(math.Float32frombits(uint32(r.uint64(4))))
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			f = 0
+		}
+		v.SetFloat(f)
+	case reflect.Float64:
+		f := math.Float64frombits(r.uint64(8))
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			f = 0
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		v.SetString(strings.ToValidUTF8(string(r.next(r.len())), ""))
+	case reflect.Slice:
+		n := r.len()
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			fill(s.Index(i), r, depth+1)
+		}
+		v.Set(s)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fill(v.Index(i), r, depth+1)
+		}
+	case reflect.Map:
+		n := r.len()
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			k := reflect.New(v.Type().Key()).Elem()
+			e := reflect.New(v.Type().Elem()).Elem()
+			fill(k, r, depth+1)
+			fill(e, r, depth+1)
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+	case reflect.Ptr:
+		if r.next(1)[0]&1 == 0 {
+			return
+		}
+		p := reflect.New(v.Type().Elem())
+		fill(p.Elem(), r, depth+1)
+		v.Set(p)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fill(v.Field(i), r, depth+1)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package optionaltest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	op "github.com/MercuryThePlanet/optional"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// struct Laws adapts an optional implementation O holding values of type V
+// for CheckLaws.
+//
+// Of, Empty and Get are required. The laws of any nil combinator are not
+// checked. Combinators may mutate or return their receiver; every law starts
+// from freshly constructed optionals.
+type Laws[O, V any] struct {
+	Of      func(V) O
+	Empty   func() O
+	Get     func(O) (V, bool)
+	Map     func(O, func(V) V) O
+	FlatMap func(O, func(V) O) O
+	Or      func(O, func() V) O
+	Filter  func(O, func(V) bool) O
+
+	// Returns a random value. If nil, values are generated by quick.Value.
+	Value func(*rand.Rand) V
+	// The functions and predicates the laws are checked with, in addition to
+	// the identity and constant functions and the constant and equality
+	// predicates.
+	Funcs []func(V) V
+	Preds []func(V) bool
+	// The number of random values CheckLaws checks. If zero, 100.
+	Count int
+}
+
+// Returns the Laws of *optional.Optional, checked with the values its
+// Generate method produces.
+func OptionalLaws() Laws[*op.Optional, op.T] {
+	return Laws[*op.Optional, op.T]{
+		Of:    op.Of,
+		Empty: op.Empty,
+		Get: func(o *op.Optional) (op.T, bool) {
+			return o.Get(), o.IsPresent()
+		},
+		Map: func(o *op.Optional, f func(op.T) op.T) *op.Optional {
+			return o.Map(f)
+		},
+		FlatMap: func(o *op.Optional, f func(op.T) *op.Optional) *op.Optional {
+			r, _ := o.FlatMap(func(t op.T) op.T {
+				return f(t)
+			}).(*op.Optional)
+			return r
+		},
+		Or: func(o *op.Optional, f func() op.T) *op.Optional {
+			return o.Or(func(op.Ts) op.T {
+				return f()
+			})
+		},
+		Filter: func(o *op.Optional, f func(op.T) bool) *op.Optional {
+			return o.Filter(f)
+		},
+		Value: func(r *rand.Rand) op.T {
+			for {
+				o := new(op.Optional).Generate(r, 50).Interface().(*op.Optional)
+				if o.IsPresent() {
+					return o.Get()
+				}
+			}
+		},
+	}
+}
+
+// Returns the Laws of optional.Option.
+func OptionLaws[V any]() Laws[op.Option[V], V] {
+	return Laws[op.Option[V], V]{
+		Of:    op.OptionOf[V],
+		Empty: op.OptionEmpty[V],
+		Get: func(o op.Option[V]) (V, bool) {
+			return o.Get(), o.IsPresent()
+		},
+		Map:     op.Map[V, V],
+		FlatMap: op.FlatMap[V, V],
+		Or: func(o op.Option[V], f func() V) op.Option[V] {
+			return o.Or(f)
+		},
+		Filter: func(o op.Option[V], f func(V) bool) op.Option[V] {
+			return o.Filter(f)
+		},
+	}
+}
+
+// Checks the laws of an optional implementation with random values:
+//
+//   - Map preserves identity and composition (functor laws).
+//   - FlatMap has Of as its left and right identity and is associative (monad
+//     laws).
+//   - Or keeps a present value and supplies a missing one.
+//   - Filter is idempotent.
+//   - If O implements json.Marshaler and O or *O json.Unmarshaler, optionals
+//     survive a JSON round trip.
+//   - If O implements driver.Valuer and O or *O sql.Scanner, optionals
+//     survive a SQL round trip.
+//
+// Nil values are never used.
+func CheckLaws[O, V any](t testing.TB, l Laws[O, V]) {
+	t.Helper()
+	n := l.Count
+	if n == 0 {
+		n = 100
+	}
+	r := rand.New(rand.NewSource(1))
+	values := make([]V, 0, n)
+	for len(values) < n {
+		v, ok := l.value(r)
+		if !ok {
+			t.Fatalf("cannot generate values of type %v; set Laws.Value",
+				reflect.TypeOf((*V)(nil)).Elem())
+		}
+		if !isNil(v) {
+			values = append(values, v)
+		}
+	}
+	CheckLawsWith(t, l, values...)
+}
+
+// Checks the laws of an optional implementation, as CheckLaws does, with the
+// given values, such as ones derived by FuzzOption. Nil values are skipped.
+func CheckLawsWith[O, V any](t testing.TB, l Laws[O, V], values ...V) {
+	t.Helper()
+	c := checker[O, V]{t: t, l: l}
+	k := *new(V)
+	for _, v := range values {
+		if isNil(v) {
+			continue
+		}
+		if isNil(k) {
+			k = v
+		}
+		c.check(v, k)
+		if t.Failed() {
+			return
+		}
+		k = v
+	}
+}
+
+func (l Laws[O, V]) value(r *rand.Rand) (V, bool) {
+	if l.Value != nil {
+		return l.Value(r), true
+	}
+	rv, ok := quick.Value(reflect.TypeOf((*V)(nil)).Elem(), r)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return rv.Interface().(V), true
+}
+
+type checker[O, V any] struct {
+	t testing.TB
+	l Laws[O, V]
+}
+
+// Checks every law for optionals built from v, using k to build constant
+// functions and predicates.
+func (c checker[O, V]) check(v, k V) {
+	l := c.l
+	builds := map[string]func() O{
+		"Of(v)":   func() O { return l.Of(v) },
+		"Empty()": l.Empty,
+	}
+	funcs := append([]func(V) V{
+		func(x V) V { return x },
+		func(V) V { return k },
+	}, l.Funcs...)
+	preds := append([]func(V) bool{
+		func(V) bool { return true },
+		func(V) bool { return false },
+		func(x V) bool { return reflect.DeepEqual(x, k) },
+	}, l.Preds...)
+	kleislis := []func(V) O{func(V) O { return l.Empty() }}
+	for _, f := range funcs {
+		f := f
+		kleislis = append(kleislis, func(x V) O { return l.Of(f(x)) })
+	}
+
+	for name, m := range builds {
+		if l.Map != nil {
+			c.equal("functor identity", name, l.Map(m(), funcs[0]), m())
+			for i, f := range funcs {
+				for j, g := range funcs {
+					c.equal(fmt.Sprintf("functor composition of funcs %d and %d", i, j), name,
+						l.Map(l.Map(m(), f), g),
+						l.Map(m(), func(x V) V { return g(f(x)) }))
+				}
+			}
+		}
+		if l.FlatMap != nil {
+			c.equal("monad right identity", name, l.FlatMap(m(), l.Of), m())
+			for i, f := range kleislis {
+				for j, g := range kleislis {
+					c.equal(fmt.Sprintf("monad associativity of funcs %d and %d", i, j), name,
+						l.FlatMap(l.FlatMap(m(), f), g),
+						l.FlatMap(m(), func(x V) O { return l.FlatMap(f(x), g) }))
+				}
+			}
+		}
+		if l.Filter != nil {
+			for i, p := range preds {
+				c.equal(fmt.Sprintf("Filter idempotence of pred %d", i), name,
+					l.Filter(l.Filter(m(), p), p), l.Filter(m(), p))
+			}
+		}
+		c.roundTrip(name, m)
+	}
+
+	if l.FlatMap != nil {
+		for i, f := range kleislis {
+			c.equal(fmt.Sprintf("monad left identity of func %d", i), "Of(v)",
+				l.FlatMap(l.Of(v), f), f(v))
+		}
+	}
+	if l.Or != nil {
+		c.equal("Or keeps a present value", "Of(v)",
+			l.Or(l.Of(v), func() V { return k }), l.Of(v))
+		c.equal("Or supplies a missing value", "Empty()",
+			l.Or(l.Empty(), func() V { return v }), l.Of(v))
+	}
+}
+
+func (c checker[O, V]) equal(law, name string, got, want O) {
+	c.t.Helper()
+	gv, gok := c.l.Get(got)
+	wv, wok := c.l.Get(want)
+	if gok != wok || gok && !reflect.DeepEqual(gv, wv) {
+		c.t.Errorf("%s does not hold for %s:\n%s", law, name,
+			Diff(describe(wv, wok), describe(gv, gok)))
+	}
+}
+
+func describe(v interface{}, ok bool) interface{} {
+	if !ok {
+		return "<empty>"
+	}
+	return v
+}
+
+// Checks the JSON and SQL round trips of the optional built by m, if O
+// supports them.
+func (c checker[O, V]) roundTrip(name string, m func() O) {
+	c.t.Helper()
+	if _, ok := any(m()).(json.Marshaler); ok {
+		if o, target := decodeTarget[O](); isType[json.Unmarshaler](target) {
+			data, err := json.Marshal(m())
+			if err == nil {
+				err = json.Unmarshal(data, target)
+			}
+			if err != nil {
+				c.t.Errorf("JSON round trip fails for %s: %v", name, err)
+			} else {
+				c.equal("JSON round trip", name, *o, m())
+			}
+		}
+	}
+	if valuer, ok := any(m()).(driver.Valuer); ok {
+		if o, target := decodeTarget[O](); isType[sql.Scanner](target) {
+			value, err := valuer.Value()
+			if err == nil {
+				err = target.(sql.Scanner).Scan(value)
+			}
+			if err != nil {
+				c.t.Errorf("SQL round trip fails for %s: %v", name, err)
+			} else {
+				c.equal("SQL round trip", name, *o, m())
+			}
+		}
+	}
+}
+
+// Returns a new O and the value to decode into it: the O itself if it is a
+// pointer, otherwise a pointer to it.
+func decodeTarget[O any]() (*O, interface{}) {
+	o := new(O)
+	t := reflect.TypeOf(o).Elem()
+	if t.Kind() == reflect.Ptr {
+		reflect.ValueOf(o).Elem().Set(reflect.New(t.Elem()))
+		return o, *o
+	}
+	return o, o
+}
+
+func isType[I any](v interface{}) bool {
+	_, ok := v.(I)
+	return ok
+}
+
+func isNil[V any](v V) bool {
+	rv := reflect.ValueOf(&v).Elem()
+	switch rv.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,193 @@
+package optionaltest_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/MercuryThePlanet/optional/optionaltest"
+	"strings"
+	"testing"
+)
+
+// optInt is a user-defined optional shaped like the code optgen generates.
+type optInt struct {
+	v       int
+	present bool
+}
+
+func (o optInt) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.v)
+}
+
+func (o *optInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = optInt{}
+		return nil
+	}
+	o.present = true
+	return json.Unmarshal(data, &o.v)
+}
+
+func (o optInt) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return int64(o.v), nil
+}
+
+func (o *optInt) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = optInt{}
+	case int64:
+		*o = optInt{int(v), true}
+	default:
+		return fmt.Errorf("cannot scan %T", src)
+	}
+	return nil
+}
+
+func optIntLaws() optionaltest.Laws[*optInt, int] {
+	return optionaltest.Laws[*optInt, int]{
+		Of:    func(v int) *optInt { return &optInt{v, true} },
+		Empty: func() *optInt { return &optInt{} },
+		Get:   func(o *optInt) (int, bool) { return o.v, o.present },
+		Map: func(o *optInt, f func(int) int) *optInt {
+			if o.present {
+				o.v = f(o.v)
+			}
+			return o
+		},
+		Filter: func(o *optInt, f func(int) bool) *optInt {
+			if o.present && !f(o.v) {
+				*o = optInt{}
+			}
+			return o
+		},
+		Funcs: []func(int) int{func(v int) int { return v * 2 }},
+		Preds: []func(int) bool{func(v int) bool { return v%2 == 0 }},
+	}
+}
+
+func Test_CheckLaws(t *testing.T) {
+	optionaltest.CheckLaws(t, optionaltest.OptionalLaws())
+	optionaltest.CheckLaws(t, optionaltest.OptionLaws[int]())
+	optionaltest.CheckLaws(t, optionaltest.OptionLaws[string]())
+	optionaltest.CheckLaws(t, optionaltest.OptionLaws[*struct{ X []int }]())
+	optionaltest.CheckLaws(t, optIntLaws())
+}
+
+func Test_CheckLawsViolations(t *testing.T) {
+	l := optIntLaws()
+	l.Filter = func(o *optInt, f func(int) bool) *optInt {
+		o.present = !o.present
+		return o
+	}
+	r := record(t, func(tb testing.TB) {
+		optionaltest.CheckLaws(tb, l)
+	})
+	if len(r.errors) == 0 || !strings.Contains(r.errors[0], "Filter idempotence") {
+		t.Errorf("Expected a Filter idempotence violation, got %q", r.errors)
+	}
+
+	l = optIntLaws()
+	l.Map = func(o *optInt, f func(int) int) *optInt {
+		return &optInt{}
+	}
+	r = record(t, func(tb testing.TB) {
+		optionaltest.CheckLaws(tb, l)
+	})
+	if len(r.errors) == 0 || !strings.Contains(r.errors[0], "functor identity") {
+		t.Errorf("Expected a functor identity violation, got %q", r.errors)
+	}
+}
+
+// lossyInt loses its value when decoded from JSON.
+type lossyInt struct{ optInt }
+
+func (o *lossyInt) UnmarshalJSON(data []byte) error {
+	*o = lossyInt{}
+	return nil
+}
+
+func Test_CheckLawsRoundTrip(t *testing.T) {
+	l := optionaltest.Laws[*lossyInt, int]{
+		Of:    func(v int) *lossyInt { return &lossyInt{optInt{v, true}} },
+		Empty: func() *lossyInt { return &lossyInt{} },
+		Get:   func(o *lossyInt) (int, bool) { return o.v, o.present },
+	}
+	r := record(t, func(tb testing.TB) {
+		optionaltest.CheckLawsWith(tb, l, 1)
+	})
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "JSON round trip does not hold for Of(v)") {
+		t.Errorf("Expected a JSON round trip violation, got %q", r.errors)
+	}
+}
+
+func Test_CheckLawsNoValues(t *testing.T) {
+	r := record(t, func(tb testing.TB) {
+		optionaltest.CheckLaws(tb, optionaltest.OptionLaws[interface{}]())
+	})
+	if !r.fatal {
+		t.Error("Expected CheckLaws to stop without a value generator.")
+	}
+}
+
+func Test_FuzzOptional(t *testing.T) {
+	optionaltest.AssertEmpty(t, optionaltest.FuzzOptional(nil))
+	optionaltest.AssertEmpty(t, optionaltest.FuzzOptional([]byte{0, 1, 2}))
+	optionaltest.AssertValue(t, optionaltest.FuzzOptional([]byte{1, 7}), 7)
+	optionaltest.AssertValue(t, optionaltest.FuzzOptional([]byte{3, 2, 'h', 'i'}), "hi")
+	optionaltest.AssertValue(t, optionaltest.FuzzOptional([]byte{5}), 0.0)
+	optionaltest.AssertValue(t, optionaltest.FuzzOptional([]byte{7, 1}), true)
+}
+
+func Test_FuzzOption(t *testing.T) {
+	type record struct {
+		N    int8
+		S    string
+		P    *uint16
+		M    map[string]bool
+		skip int
+	}
+	optionaltest.AssertEmpty(t, optionaltest.FuzzOption[int]([]byte{2, 1}))
+	optionaltest.AssertValue(t, optionaltest.FuzzOption[int8]([]byte{1, 0xff}), int8(-1))
+	optionaltest.AssertValue(t, optionaltest.FuzzOption[float64]([]byte{
+		1, 0, 0, 0, 0, 0, 0, 0xf8, 0x7f}), 0.0)
+	optionaltest.AssertValue(t, optionaltest.FuzzOption[string]([]byte{1, 3, 'a', 0xff, 'b'}), "ab")
+
+	p := uint16(0x0201)
+	want := record{N: 5, S: "x", P: &p, M: map[string]bool{"k": true}}
+	data := []byte{1, 5, 1, 'x', 1, 1, 2, 1, 1, 'k', 1}
+	optionaltest.AssertValue(t, optionaltest.FuzzOption[record](data), want)
+}
+
+func FuzzOptionalLaws(f *testing.F) {
+	f.Add([]byte{1, 7})
+	f.Add([]byte{3, 2, 'h', 'i'})
+	f.Add([]byte{5, 1, 2, 3})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if o := optionaltest.FuzzOptional(data); o.IsPresent() {
+			optionaltest.CheckLawsWith(t, optionaltest.OptionalLaws(), o.Get())
+		}
+	})
+}
+
+func FuzzOptionLaws(f *testing.F) {
+	f.Add([]byte{1, 2, 'h', 'i', 1, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		o := optionaltest.FuzzOption[struct {
+			S string
+			N []int
+		}](data)
+		if o.IsPresent() {
+			optionaltest.CheckLawsWith(t, optionaltest.OptionLaws[struct {
+				S string
+				N []int
+			}](), o.Get())
+		}
+	})
+}
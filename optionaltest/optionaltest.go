@@ -0,0 +1,232 @@
+// package optionaltest provides assertions for tests of code using optionals.
+//
+// Every assertion takes a testing.TB, marks itself as a helper and reports
+// failures through it, so it can be used from tests, benchmarks and fuzz
+// targets alike. Assertions return whether they passed; RequireValue stops the
+// test instead.
+package optionaltest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Optional is implemented by *optional.Optional, optional.Option and the
+// types built on them. The value of a present Optional is read through its Get
+// method.
+type Optional interface {
+	IsPresent() bool
+}
+
+// Asserts that o holds a value.
+func AssertPresent(t testing.TB, o Optional) bool {
+	t.Helper()
+	if !present(o) {
+		t.Errorf("expected a present optional, got empty %T", o)
+		return false
+	}
+	return true
+}
+
+// Asserts that o holds no value.
+func AssertEmpty(t testing.TB, o Optional) bool {
+	t.Helper()
+	if present(o) {
+		t.Errorf("expected an empty optional, got %#v", get(o))
+		return false
+	}
+	return true
+}
+
+// Asserts that o holds a value deeply equal to want. On failure the
+// differences between the values are listed.
+func AssertValue(t testing.TB, o Optional, want interface{}) bool {
+	t.Helper()
+	if !present(o) {
+		t.Errorf("expected optional with value %#v, got empty %T", want, o)
+		return false
+	}
+	got := get(o)
+	if reflect.DeepEqual(got, want) {
+		return true
+	}
+	t.Errorf("optional value mismatch (-want +got):\n%s", Diff(want, got))
+	return false
+}
+
+// Asserts that f panics and returns the recovered value.
+func AssertPanics(t testing.TB, f func()) (recovered interface{}) {
+	t.Helper()
+	panicked := true
+	func() {
+		defer func() {
+			if panicked {
+				recovered = recover()
+			}
+		}()
+		f()
+		panicked = false
+	}()
+	if !panicked {
+		t.Errorf("expected a panic")
+	}
+	return recovered
+}
+
+// Returns the value of o as a V, stopping the test if o is empty or its value
+// is not a V.
+func RequireValue[V any](t testing.TB, o Optional) V {
+	t.Helper()
+	if !present(o) {
+		t.Fatalf("expected a present optional, got empty %T", o)
+	}
+	v, ok := get(o).(V)
+	if !ok {
+		t.Fatalf("expected optional value of type %v, got %T",
+			reflect.TypeOf((*V)(nil)).Elem(), get(o))
+	}
+	return v
+}
+
+// Returns a line per difference between want and got, each prefixed by the
+// path to the differing value. Structs, maps, slices, arrays and pointers are
+// compared element by element, down to a fixed depth.
+func Diff(want, got interface{}) string {
+	var b strings.Builder
+	diff(&b, "", reflect.ValueOf(want), reflect.ValueOf(got), 0)
+	if b.Len() == 0 && !reflect.DeepEqual(want, got) {
+		line(&b, "", reflect.ValueOf(want), reflect.ValueOf(got))
+	}
+	return b.String()
+}
+
+// The depth below which Diff stops descending, which also bounds cyclic
+// values.
+const maxDepth = 32
+
+func diff(b *strings.Builder, path string, want, got reflect.Value, depth int) {
+	if !want.IsValid() || !got.IsValid() || want.Type() != got.Type() || depth > maxDepth {
+		line(b, path, want, got)
+		return
+	}
+	switch want.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if want.IsNil() || got.IsNil() {
+			if want.IsNil() != got.IsNil() {
+				line(b, path, want, got)
+			}
+			return
+		}
+		diff(b, path, want.Elem(), got.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < want.NumField(); i++ {
+			diff(b, path+"."+want.Type().Field(i).Name, want.Field(i), got.Field(i), depth+1)
+		}
+	case reflect.Slice, reflect.Array:
+		n := want.Len()
+		if got.Len() > n {
+			n = got.Len()
+		}
+		for i := 0; i < n; i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= want.Len():
+				line(b, p, reflect.Value{}, got.Index(i))
+			case i >= got.Len():
+				line(b, p, want.Index(i), reflect.Value{})
+			default:
+				diff(b, p, want.Index(i), got.Index(i), depth+1)
+			}
+		}
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, k := range append(want.MapKeys(), got.MapKeys()...) {
+			keys[format(k)] = k
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			k := keys[name]
+			diff(b, path+"["+name+"]", want.MapIndex(k), got.MapIndex(k), depth+1)
+		}
+	default:
+		w, wok := leaf(want)
+		g, gok := leaf(got)
+		if !wok || !gok || !reflect.DeepEqual(w, g) {
+			line(b, path, want, got)
+		}
+	}
+}
+
+func line(b *strings.Builder, path string, want, got reflect.Value) {
+	if path == "" {
+		path = "value"
+	}
+	typed := want.IsValid() && got.IsValid() && want.Type() != got.Type()
+	for _, l := range []struct {
+		sign string
+		v    reflect.Value
+	}{{"-", want}, {"+", got}} {
+		if !l.v.IsValid() {
+			continue
+		}
+		if typed {
+			fmt.Fprintf(b, "%s%s: %s (%v)\n", l.sign, path, format(l.v), l.v.Type())
+		} else {
+			fmt.Fprintf(b, "%s%s: %s\n", l.sign, path, format(l.v))
+		}
+	}
+}
+
+func format(v reflect.Value) string {
+	if x, ok := leaf(v); ok {
+		return fmt.Sprintf("%#v", x)
+	}
+	return fmt.Sprintf("<%v>", v.Type())
+}
+
+// Returns the value held by v. Values of unexported fields are read through
+// their kind, which is not possible for funcs, channels and unsafe pointers.
+func leaf(v reflect.Value) (interface{}, bool) {
+	if v.CanInterface() {
+		return v.Interface(), true
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return v.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex(), true
+	case reflect.String:
+		return v.String(), true
+	}
+	return nil, false
+}
+
+func present(o Optional) bool {
+	v := reflect.ValueOf(o)
+	if !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		return false
+	}
+	return o.IsPresent()
+}
+
+func get(o Optional) interface{} {
+	m := reflect.ValueOf(o).MethodByName("Get")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		panic(fmt.Sprintf("optionaltest: %T has no Get method", o))
+	}
+	return m.Call(nil)[0].Interface()
+}
@@ -0,0 +1,156 @@
+package optionaltest_test
+
+import (
+	"fmt"
+	op "github.com/MercuryThePlanet/optional"
+	"github.com/MercuryThePlanet/optional/optionaltest"
+	"strings"
+	"testing"
+)
+
+// recorder captures the failures reported by an assertion.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	panic(r)
+}
+
+// Runs f against a recorder, recovering from the panic Fatalf stops it with.
+func record(t *testing.T, f func(testing.TB)) *recorder {
+	r := &recorder{TB: t}
+	func() {
+		defer func() {
+			if p := recover(); p != nil && p != r {
+				panic(p)
+			}
+		}()
+		f(r)
+	}()
+	return r
+}
+
+func expectFailure(t *testing.T, r *recorder, contains string) {
+	t.Helper()
+	if len(r.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %d: %q", len(r.errors), r.errors)
+	}
+	if !strings.Contains(r.errors[0], contains) {
+		t.Errorf("Expected failure containing `%v`, got `%v`", contains, r.errors[0])
+	}
+}
+
+type point struct {
+	X, Y  int
+	Tags  []string
+	Attrs map[string]int
+	label string
+}
+
+func Test_AssertPresent(t *testing.T) {
+	if !optionaltest.AssertPresent(t, op.Of(1)) || !optionaltest.AssertPresent(t, op.OptionOf("a")) {
+		t.Error("Expected AssertPresent to pass.")
+	}
+
+	var nilOptional *op.Optional
+	for _, o := range []optionaltest.Optional{op.Empty(), op.OptionEmpty[int](), nilOptional} {
+		r := record(t, func(tb testing.TB) {
+			if optionaltest.AssertPresent(tb, o) {
+				t.Error("Expected AssertPresent to fail.")
+			}
+		})
+		expectFailure(t, r, "expected a present optional")
+	}
+}
+
+func Test_AssertEmpty(t *testing.T) {
+	if !optionaltest.AssertEmpty(t, op.Empty()) || !optionaltest.AssertEmpty(t, op.EmptyInt()) {
+		t.Error("Expected AssertEmpty to pass.")
+	}
+
+	r := record(t, func(tb testing.TB) {
+		optionaltest.AssertEmpty(tb, op.OfString("a"))
+	})
+	expectFailure(t, r, `got "a"`)
+}
+
+func Test_AssertValue(t *testing.T) {
+	optionaltest.AssertValue(t, op.Of(1), 1)
+	optionaltest.AssertValue(t, op.OfInt(1), 1)
+	optionaltest.AssertValue(t, op.Of([]int{1, 2}), []int{1, 2})
+
+	r := record(t, func(tb testing.TB) {
+		optionaltest.AssertValue(tb, op.Empty(), 1)
+	})
+	expectFailure(t, r, "expected optional with value 1, got empty")
+
+	r = record(t, func(tb testing.TB) {
+		optionaltest.AssertValue(tb, op.Of(int64(1)), 1)
+	})
+	expectFailure(t, r, "-value: 1 (int)\n+value: 1 (int64)\n")
+}
+
+func Test_Diff(t *testing.T) {
+	want := point{1, 2, []string{"a"}, map[string]int{"a": 1, "b": 2}, "p"}
+	got := point{1, 3, []string{"a", "b"}, map[string]int{"a": 1, "c": 3}, "q"}
+	expected := strings.Join([]string{
+		"-.Y: 2",
+		"+.Y: 3",
+		`+.Tags[1]: "b"`,
+		`-.Attrs["b"]: 2`,
+		`+.Attrs["c"]: 3`,
+		`-.label: "p"`,
+		`+.label: "q"`,
+	}, "\n") + "\n"
+	if d := optionaltest.Diff(want, got); d != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, d)
+	}
+	if d := optionaltest.Diff(&want, &want); d != "" {
+		t.Errorf("Expected no diff, got:\n%s", d)
+	}
+}
+
+func Test_AssertPanics(t *testing.T) {
+	if p := optionaltest.AssertPanics(t, func() {
+		op.Empty().OrElsePanic("boom")
+	}); p != "boom" {
+		t.Errorf("Expected `boom`, got `%v`", p)
+	}
+
+	r := record(t, func(tb testing.TB) {
+		optionaltest.AssertPanics(tb, func() {})
+	})
+	expectFailure(t, r, "expected a panic")
+}
+
+func Test_RequireValue(t *testing.T) {
+	if v := optionaltest.RequireValue[string](t, op.Of("a")); v != "a" {
+		t.Errorf("Expected `a`, got `%v`", v)
+	}
+	if v := optionaltest.RequireValue[int](t, op.OptionOf(1)); v != 1 {
+		t.Errorf("Expected `1`, got `%v`", v)
+	}
+
+	r := record(t, func(tb testing.TB) {
+		optionaltest.RequireValue[int](tb, op.Empty())
+		t.Error("RequireValue should stop the test.")
+	})
+	expectFailure(t, r, "expected a present optional")
+
+	r = record(t, func(tb testing.TB) {
+		optionaltest.RequireValue[int](tb, op.Of("a"))
+		t.Error("RequireValue should stop the test.")
+	})
+	expectFailure(t, r, "expected optional value of type int, got string")
+}
@@ -0,0 +1,192 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Returns an Option describing the value p points to, if p is non-nil,
+// otherwise returns an empty Option.
+func FromPtr[V any](p *V) Option[V] {
+	if p == nil {
+		return Option[V]{}
+	}
+	return OptionOfNilable(*p)
+}
+
+// If a value is present, returns a pointer to a new copy of the value,
+// otherwise returns nil.
+func (o Option[V]) ToPtr() *V {
+	if !o.present {
+		return nil
+	}
+	v := o.v
+	return &v
+}
+
+// Returns an Optional describing the value p points to, if p is a non-nil
+// pointer, otherwise returns an empty Optional. Panics if p is not a pointer.
+func OptionalFromPtr(p T) *Optional {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr {
+		panic(fmt.Sprintf("optional.OptionalFromPtr takes a pointer, got %T", p))
+	}
+	o := &Optional{}
+	o.fromPtr(v)
+	return o
+}
+
+// If a value is present, returns a pointer to a new copy of the value,
+// otherwise returns nil.
+func (o *Optional) ToPtr() T {
+	if !o.present {
+		return nil
+	}
+	p, _ := o.toPtr(reflect.PtrTo(reflect.TypeOf(o.t)))
+	return p.Interface()
+}
+
+// Implemented by the optionals FromPtrFields and ToPtrFields convert.
+type ptrConverter interface {
+	// Sets the optional from the pointer p, reporting whether the values p
+	// points to can be held.
+	fromPtr(p reflect.Value) bool
+	// Returns a pointer of type t to a copy of the value, or a nil pointer if
+	// there is none, reporting whether the value can be pointed to by t.
+	toPtr(t reflect.Type) (reflect.Value, bool)
+}
+
+func (o *Optional) fromPtr(p reflect.Value) bool {
+	o.typ = p.Type().Elem()
+	if p.IsNil() {
+		o.set(nil, false)
+	} else {
+		o.set(p.Elem().Interface(), true)
+	}
+	return true
+}
+
+func (o *Optional) toPtr(t reflect.Type) (reflect.Value, bool) {
+	if !o.present {
+		return reflect.Zero(t), true
+	}
+	if !reflect.TypeOf(o.t).AssignableTo(t.Elem()) {
+		return reflect.Value{}, false
+	}
+	p := reflect.New(t.Elem())
+	p.Elem().Set(reflect.ValueOf(o.t))
+	return p, true
+}
+
+func (o *Option[V]) fromPtr(p reflect.Value) bool {
+	typ := reflect.TypeOf((*V)(nil)).Elem()
+	if !p.Type().Elem().AssignableTo(typ) {
+		return false
+	}
+	if p.IsNil() {
+		*o = Option[V]{}
+		return true
+	}
+	v := reflect.New(typ).Elem()
+	v.Set(p.Elem())
+	*o = OptionOfNilable(v.Interface().(V))
+	return true
+}
+
+func (o *Option[V]) toPtr(t reflect.Type) (reflect.Value, bool) {
+	if !reflect.TypeOf((*V)(nil)).Elem().AssignableTo(t.Elem()) {
+		return reflect.Value{}, false
+	}
+	if !o.present {
+		return reflect.Zero(t), true
+	}
+	p := reflect.New(t.Elem())
+	p.Elem().Set(reflect.ValueOf(&o.v).Elem())
+	return p, true
+}
+
+// Copies the fields of the struct src, or the struct src points to, into the
+// struct dst points to, converting pointer fields of src into optional fields
+// of dst. Fields are matched by name; fields without a match are left alone.
+//
+// A nil pointer gives an empty optional, any other pointer one describing the
+// value it points to. Optional fields may be Optional, *Optional, Option or a
+// type embedding Option, such as OptionalInt. Fields of assignable types are
+// copied as is. Fields that cannot be converted are reported as *FieldError,
+// joined with errors.Join.
+func FromPtrFields(dst, src T) error {
+	return copyFields("FromPtrFields", dst, src, func(d, s reflect.Value) bool {
+		c, ok := converter(d, true)
+		return ok && s.Kind() == reflect.Ptr && c.fromPtr(s)
+	})
+}
+
+// Copies the fields of the struct src, or the struct src points to, into the
+// struct dst points to, converting optional fields of src into pointer fields
+// of dst. It is the reverse of FromPtrFields: an empty optional gives a nil
+// pointer, a present one a pointer to a new copy of its value.
+func ToPtrFields(dst, src T) error {
+	return copyFields("ToPtrFields", dst, src, func(d, s reflect.Value) bool {
+		c, ok := converter(s, false)
+		if !ok || d.Kind() != reflect.Ptr {
+			return false
+		}
+		p, ok := c.toPtr(d.Type())
+		if ok {
+			d.Set(p)
+		}
+		return ok
+	})
+}
+
+func copyFields(fn string, dst, src T, convert func(d, s reflect.Value) bool) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optional: %s takes a struct pointer destination, got %T", fn, dst)
+	}
+	dv = dv.Elem()
+	sv := reflect.Indirect(reflect.ValueOf(src))
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("optional: %s takes a struct source, got %T", fn, src)
+	}
+
+	var errs []error
+	for i := 0; i < dv.NumField(); i++ {
+		field := dv.Type().Field(i)
+		sf, ok := sv.Type().FieldByName(field.Name)
+		if !ok || !field.IsExported() || !sf.IsExported() || len(sf.Index) != 1 {
+			continue
+		}
+		d, s := dv.Field(i), sv.Field(sf.Index[0])
+		if s.Type().AssignableTo(d.Type()) {
+			d.Set(s)
+		} else if !convert(d, s) {
+			errs = append(errs, &FieldError{field.Name,
+				fmt.Errorf("cannot convert %v to %v", s.Type(), d.Type())})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Returns the ptrConverter of the optional field v. A nil *Optional field is
+// read as empty, and set to a new empty Optional first if alloc is true.
+func converter(v reflect.Value, alloc bool) (ptrConverter, bool) {
+	if v.Type() == reflect.TypeOf((*Optional)(nil)) {
+		if v.IsNil() {
+			o := Empty()
+			if alloc {
+				v.Set(reflect.ValueOf(o))
+			}
+			return o, true
+		}
+		return v.Interface().(*Optional), true
+	}
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+	c, ok := v.Addr().Interface().(ptrConverter)
+	return c, ok
+}
@@ -0,0 +1,198 @@
+package optional_test
+
+import (
+	"errors"
+	op "github.com/MercuryThePlanet/optional"
+	"strings"
+	"testing"
+)
+
+func Test_FromPtr(t *testing.T) {
+	t.Run("FromPtr", FromPtr_test)
+	t.Run("ToPtr", ToPtr_test)
+	t.Run("OptionalFromPtr", OptionalFromPtr_test)
+	t.Run("OptionalFromPtr not a pointer", OptionalFromPtrNotPointer_test)
+	t.Run("Optional ToPtr", OptionalToPtr_test)
+}
+
+func FromPtr_test(t *testing.T) {
+	defer shouldNotPanic("optional.FromPtr", t)
+
+	s := TEST_STR
+	if v := op.FromPtr(&s).Get(); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+	if op.FromPtr[string](nil).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	var e *error
+	if op.FromPtr(&e).IsPresent() {
+		t.Error("Pointer to nil should be empty.")
+	}
+}
+
+func ToPtr_test(t *testing.T) {
+	defer shouldNotPanic("optional.ToPtr", t)
+
+	o := op.OptionOf(TEST_INT)
+	p := o.ToPtr()
+	if p == nil || *p != TEST_INT {
+		t.Fatalf("Expected pointer to `%v`, got `%v`", TEST_INT, p)
+	}
+	if *p = TEST_OTHER; o.Get() != TEST_INT || o.ToPtr() == p {
+		t.Error("ToPtr should return a fresh pointer.")
+	}
+	if op.OptionEmpty[int]().ToPtr() != nil {
+		t.Error("Empty Option should give nil.")
+	}
+}
+
+func OptionalFromPtr_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalFromPtr", t)
+
+	i := TEST_INT
+	if v := op.OptionalFromPtr(&i).Get().(int); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	var nilInt *int
+	o := op.OptionalFromPtr(nilInt)
+	if o.IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	if err := o.UnmarshalText([]byte(TEST_STR)); err != nil || o.Get() != TEST_INT {
+		t.Errorf("Empty Optional should decode into the pointed to type, got `%v`, `%v`", o.Get(), err)
+	}
+}
+
+func OptionalFromPtrNotPointer_test(t *testing.T) {
+	defer shouldPanic("optional.OptionalFromPtr with a value", t)
+
+	op.OptionalFromPtr(TEST_INT)
+	t.Fatal("this code should not be reachable.")
+}
+
+func OptionalToPtr_test(t *testing.T) {
+	defer shouldNotPanic("optional.ToPtr", t)
+
+	p, ok := op.Of(TEST_STR).ToPtr().(*string)
+	if !ok || *p != TEST_STR {
+		t.Errorf("Expected pointer to `%v`, got `%v`", TEST_STR, p)
+	}
+	if op.Empty().ToPtr() != nil {
+		t.Error("Empty Optional should give nil.")
+	}
+}
+
+type UserDTO struct {
+	ID       int
+	Name     *string
+	Age      *int
+	Email    *string
+	Nickname *string
+	Tags     *[]string
+	Extra    *float64
+}
+
+type User struct {
+	ID       int
+	Name     op.OptionalString
+	Age      op.Option[int]
+	Email    *op.Optional
+	Nickname op.Optional
+	Tags     op.Option[[]string]
+	Missing  op.Option[bool]
+}
+
+func Test_PtrFields(t *testing.T) {
+	t.Run("FromPtrFields", FromPtrFields_test)
+	t.Run("ToPtrFields", ToPtrFields_test)
+	t.Run("PtrFields mismatch", PtrFieldsMismatch_test)
+	t.Run("PtrFields invalid arguments", PtrFieldsInvalid_test)
+}
+
+func FromPtrFields_test(t *testing.T) {
+	defer shouldNotPanic("optional.FromPtrFields", t)
+
+	name, email := "ann", "ann@example.com"
+	dto := UserDTO{ID: 1, Name: &name, Email: &email, Tags: &[]string{"a"}}
+	var u User
+	if err := op.FromPtrFields(&u, dto); err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != 1 || u.Name.Get() != name || u.Age.IsPresent() || u.Nickname.IsPresent() {
+		t.Errorf("Unexpected user `%+v`", u)
+	}
+	if u.Email == nil || u.Email.Get() != email {
+		t.Errorf("Expected email `%v`, got `%v`", email, u.Email)
+	}
+	if tags := u.Tags.Get(); len(tags) != 1 || tags[0] != "a" {
+		t.Errorf("Expected tags `[a]`, got `%v`", tags)
+	}
+}
+
+func ToPtrFields_test(t *testing.T) {
+	defer shouldNotPanic("optional.ToPtrFields", t)
+
+	u := User{ID: 2, Name: op.OfString("bob"), Age: op.OptionOf(40), Nickname: *op.Of("b")}
+	var dto UserDTO
+	if err := op.ToPtrFields(&dto, &u); err != nil {
+		t.Fatal(err)
+	}
+	if dto.ID != 2 || dto.Name == nil || *dto.Name != "bob" || dto.Age == nil || *dto.Age != 40 {
+		t.Errorf("Unexpected DTO `%+v`", dto)
+	}
+	if dto.Nickname == nil || *dto.Nickname != "b" {
+		t.Errorf("Expected nickname `b`, got `%v`", dto.Nickname)
+	}
+	if dto.Email != nil || dto.Tags != nil || dto.Extra != nil {
+		t.Errorf("Empty optionals should give nil pointers, got `%+v`", dto)
+	}
+	if u.Email != nil {
+		t.Errorf("The source should be unchanged, got email `%v`", u.Email)
+	}
+
+	var back User
+	if err := op.FromPtrFields(&back, dto); err != nil {
+		t.Fatal(err)
+	}
+	if back.Name != u.Name || back.Age != u.Age || back.Nickname.Get() != "b" {
+		t.Errorf("Expected `%+v`, got `%+v`", u, back)
+	}
+}
+
+func PtrFieldsMismatch_test(t *testing.T) {
+	defer shouldNotPanic("optional.FromPtrFields", t)
+
+	var dst struct {
+		Name op.Option[int]
+		Age  string
+	}
+	name := "ann"
+	err := op.FromPtrFields(&dst, UserDTO{Name: &name})
+	var fe *op.FieldError
+	if !errors.As(err, &fe) || fe.Field != "Name" {
+		t.Fatalf("Expected a FieldError for Name, got `%v`", err)
+	}
+	if !strings.Contains(err.Error(), "Age: cannot convert *int to string") {
+		t.Errorf("Expected an error for Age, got `%v`", err)
+	}
+
+	var back struct{ Name *int }
+	if err := op.ToPtrFields(&back, User{Name: op.OfString("ann")}); err == nil {
+		t.Error("Expected an error converting a string optional to *int.")
+	}
+	if err := op.ToPtrFields(&back, struct{ Name op.Optional }{*op.Of("ann")}); err == nil {
+		t.Error("Expected an error converting a string Optional to *int.")
+	}
+}
+
+func PtrFieldsInvalid_test(t *testing.T) {
+	defer shouldNotPanic("optional.FromPtrFields", t)
+
+	if err := op.FromPtrFields(User{}, UserDTO{}); err == nil {
+		t.Error("Expected an error for a struct destination.")
+	}
+	if err := op.ToPtrFields(&UserDTO{}, TEST_INT); err == nil {
+		t.Error("Expected an error for a non-struct source.")
+	}
+}
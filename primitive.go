@@ -0,0 +1,209 @@
+package optional
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// struct OptionalInt is an Option of int with checked arithmetic.
+type OptionalInt struct {
+	Option[int]
+}
+
+// struct OptionalFloat64 is an Option of float64 with checked arithmetic.
+type OptionalFloat64 struct {
+	Option[float64]
+}
+
+// struct OptionalString is an Option of string with text helpers.
+type OptionalString struct {
+	Option[string]
+}
+
+// struct OptionalBool is an Option of bool.
+type OptionalBool struct {
+	Option[bool]
+}
+
+// Returns an empty OptionalInt instance.
+func EmptyInt() OptionalInt {
+	return OptionalInt{}
+}
+
+// Returns an OptionalInt describing the given value.
+func OfInt(v int) OptionalInt {
+	return OptionalInt{OptionOf(v)}
+}
+
+// Returns an empty OptionalFloat64 instance.
+func EmptyFloat64() OptionalFloat64 {
+	return OptionalFloat64{}
+}
+
+// Returns an OptionalFloat64 describing the given value.
+func OfFloat64(v float64) OptionalFloat64 {
+	return OptionalFloat64{OptionOf(v)}
+}
+
+// Returns an empty OptionalString instance.
+func EmptyString() OptionalString {
+	return OptionalString{}
+}
+
+// Returns an OptionalString describing the given value.
+func OfString(v string) OptionalString {
+	return OptionalString{OptionOf(v)}
+}
+
+// Returns an empty OptionalBool instance.
+func EmptyBool() OptionalBool {
+	return OptionalBool{}
+}
+
+// Returns an OptionalBool describing the given value.
+func OfBool(v bool) OptionalBool {
+	return OptionalBool{OptionOf(v)}
+}
+
+// If both values are present, returns an OptionalInt describing their sum,
+// otherwise, or if the sum overflows, returns an empty OptionalInt.
+func (o OptionalInt) Add(other OptionalInt) OptionalInt {
+	if !o.present || !other.present {
+		return OptionalInt{}
+	}
+	a, b := o.v, other.v
+	c := a + b
+	if (c > a) != (b > 0) {
+		return OptionalInt{}
+	}
+	return OfInt(c)
+}
+
+// If both values are present, returns an OptionalInt describing their
+// difference, otherwise, or if the difference overflows, returns an empty
+// OptionalInt.
+func (o OptionalInt) Sub(other OptionalInt) OptionalInt {
+	if !o.present || !other.present {
+		return OptionalInt{}
+	}
+	a, b := o.v, other.v
+	c := a - b
+	if (c < a) != (b > 0) {
+		return OptionalInt{}
+	}
+	return OfInt(c)
+}
+
+// If both values are present, returns an OptionalInt describing their
+// product, otherwise, or if the product overflows, returns an empty
+// OptionalInt.
+func (o OptionalInt) Mul(other OptionalInt) OptionalInt {
+	if !o.present || !other.present {
+		return OptionalInt{}
+	}
+	a, b := o.v, other.v
+	if a == 0 || b == 0 {
+		return OfInt(0)
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return OptionalInt{}
+	}
+	return OfInt(c)
+}
+
+// If both values are present, returns an OptionalInt describing their
+// quotient, truncated towards zero, otherwise, or if other is zero or the
+// quotient overflows, returns an empty OptionalInt.
+func (o OptionalInt) Div(other OptionalInt) OptionalInt {
+	if !o.present || !other.present || other.v == 0 {
+		return OptionalInt{}
+	}
+	if o.v == math.MinInt && other.v == -1 {
+		return OptionalInt{}
+	}
+	return OfInt(o.v / other.v)
+}
+
+// If both values are present, returns an OptionalFloat64 describing their sum,
+// otherwise, or if the sum is not finite, returns an empty OptionalFloat64.
+func (o OptionalFloat64) Add(other OptionalFloat64) OptionalFloat64 {
+	return o.apply(other, func(a, b float64) float64 { return a + b })
+}
+
+// If both values are present, returns an OptionalFloat64 describing their
+// difference, otherwise, or if the difference is not finite, returns an empty
+// OptionalFloat64.
+func (o OptionalFloat64) Sub(other OptionalFloat64) OptionalFloat64 {
+	return o.apply(other, func(a, b float64) float64 { return a - b })
+}
+
+// If both values are present, returns an OptionalFloat64 describing their
+// product, otherwise, or if the product is not finite, returns an empty
+// OptionalFloat64.
+func (o OptionalFloat64) Mul(other OptionalFloat64) OptionalFloat64 {
+	return o.apply(other, func(a, b float64) float64 { return a * b })
+}
+
+// If both values are present, returns an OptionalFloat64 describing their
+// quotient, otherwise, or if other is zero or the quotient is not finite,
+// returns an empty OptionalFloat64.
+func (o OptionalFloat64) Div(other OptionalFloat64) OptionalFloat64 {
+	if other.present && other.v == 0 {
+		return OptionalFloat64{}
+	}
+	return o.apply(other, func(a, b float64) float64 { return a / b })
+}
+
+func (o OptionalFloat64) apply(other OptionalFloat64, f func(a, b float64) float64) OptionalFloat64 {
+	if !o.present || !other.present {
+		return OptionalFloat64{}
+	}
+	c := f(o.v, other.v)
+	if math.IsInf(c, 0) || math.IsNaN(c) {
+		return OptionalFloat64{}
+	}
+	return OfFloat64(c)
+}
+
+// If a value is present, returns an OptionalString describing the value with
+// leading and trailing white space removed, otherwise returns an empty
+// OptionalString.
+func (o OptionalString) TrimSpace() OptionalString {
+	if !o.present {
+		return o
+	}
+	return OfString(strings.TrimSpace(o.v))
+}
+
+// If a value is present and contains a non-white space character, returns the
+// OptionalString, otherwise returns an empty OptionalString.
+func (o OptionalString) NonBlank() OptionalString {
+	if !o.present || strings.TrimSpace(o.v) == "" {
+		return OptionalString{}
+	}
+	return o
+}
+
+// If a value is present and is a base 10 integer, returns an OptionalInt
+// describing the parsed integer, otherwise returns an empty OptionalInt.
+func (o OptionalString) ParseInt() OptionalInt {
+	if !o.present {
+		return OptionalInt{}
+	}
+	i, err := strconv.Atoi(o.v)
+	if err != nil {
+		return OptionalInt{}
+	}
+	return OfInt(i)
+}
+
+// If a value is present, returns an OptionalBool describing its negation,
+// otherwise returns an empty OptionalBool.
+func (o OptionalBool) Not() OptionalBool {
+	if !o.present {
+		return o
+	}
+	return OfBool(!o.v)
+}
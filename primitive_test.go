@@ -0,0 +1,182 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"math"
+	"testing"
+)
+
+func Test_OptionalInt(t *testing.T) {
+	t.Run("OptionalInt", OptionalInt_test)
+	t.Run("OptionalInt arithmetic", OptionalIntArithmetic_test)
+	t.Run("OptionalInt overflow", OptionalIntOverflow_test)
+	t.Run("OptionalInt divide by zero", OptionalIntDivZero_test)
+	t.Run("OptionalInt empty operand", OptionalIntEmptyOperand_test)
+}
+
+func OptionalInt_test(t *testing.T) {
+	defer shouldNotPanic("optional.OfInt", t)
+
+	if v := op.OfInt(TEST_INT).OrElse(TEST_OTHER); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if v := op.EmptyInt().OrElse(TEST_OTHER); v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+	if op.EmptyInt() != (op.OptionalInt{}) {
+		t.Error("Empty OptionalInt should be the zero OptionalInt.")
+	}
+}
+
+func OptionalIntArithmetic_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalInt", t)
+
+	a, b := op.OfInt(7), op.OfInt(-2)
+	for _, c := range []struct {
+		name string
+		got  op.OptionalInt
+		want int
+	}{
+		{"Add", a.Add(b), 5},
+		{"Sub", a.Sub(b), 9},
+		{"Mul", a.Mul(b), -14},
+		{"Div", a.Div(b), -3},
+		{"Mul zero", op.OfInt(math.MinInt).Mul(op.OfInt(0)), 0},
+	} {
+		if v := c.got.OrElsePanic(c.name + " should be present."); v != c.want {
+			t.Errorf("%s: expected `%v`, got `%v`", c.name, c.want, v)
+		}
+	}
+}
+
+func OptionalIntOverflow_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalInt", t)
+
+	max, min := op.OfInt(math.MaxInt), op.OfInt(math.MinInt)
+	for name, o := range map[string]op.OptionalInt{
+		"Add":           max.Add(op.OfInt(1)),
+		"Add negative":  min.Add(op.OfInt(-1)),
+		"Sub":           min.Sub(op.OfInt(1)),
+		"Sub negative":  max.Sub(op.OfInt(-1)),
+		"Mul":           max.Mul(op.OfInt(2)),
+		"Mul MinInt -1": min.Mul(op.OfInt(-1)),
+		"Mul -1 MinInt": op.OfInt(-1).Mul(min),
+		"Div MinInt -1": min.Div(op.OfInt(-1)),
+	} {
+		if o.IsPresent() {
+			t.Errorf("%s: overflow should be empty, got `%v`", name, o.Get())
+		}
+	}
+}
+
+func OptionalIntDivZero_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalInt.Div", t)
+
+	if op.OfInt(TEST_INT).Div(op.OfInt(0)).IsPresent() {
+		t.Error("Division by zero should be empty.")
+	}
+}
+
+func OptionalIntEmptyOperand_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalInt", t)
+
+	if op.OfInt(TEST_INT).Add(op.EmptyInt()).IsPresent() || op.EmptyInt().Mul(op.OfInt(1)).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func Test_OptionalFloat64(t *testing.T) {
+	t.Run("OptionalFloat64 arithmetic", OptionalFloat64Arithmetic_test)
+	t.Run("OptionalFloat64 not finite", OptionalFloat64NotFinite_test)
+}
+
+func OptionalFloat64Arithmetic_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalFloat64", t)
+
+	a, b := op.OfFloat64(1.5), op.OfFloat64(0.5)
+	for _, c := range []struct {
+		name string
+		got  op.OptionalFloat64
+		want float64
+	}{
+		{"Add", a.Add(b), 2},
+		{"Sub", a.Sub(b), 1},
+		{"Mul", a.Mul(b), 0.75},
+		{"Div", a.Div(b), 3},
+	} {
+		if v := c.got.OrElsePanic(c.name + " should be present."); v != c.want {
+			t.Errorf("%s: expected `%v`, got `%v`", c.name, c.want, v)
+		}
+	}
+}
+
+func OptionalFloat64NotFinite_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalFloat64", t)
+
+	max := op.OfFloat64(math.MaxFloat64)
+	for name, o := range map[string]op.OptionalFloat64{
+		"Div zero":  op.OfFloat64(1).Div(op.OfFloat64(0)),
+		"Mul":       max.Mul(max),
+		"Add":       max.Add(max),
+		"Empty":     op.EmptyFloat64().Add(op.OfFloat64(1)),
+		"Div empty": op.OfFloat64(1).Div(op.EmptyFloat64()),
+	} {
+		if o.IsPresent() {
+			t.Errorf("%s: should be empty, got `%v`", name, o.Get())
+		}
+	}
+}
+
+func Test_OptionalString(t *testing.T) {
+	t.Run("TrimSpace", OptionalStringTrimSpace_test)
+	t.Run("NonBlank", OptionalStringNonBlank_test)
+	t.Run("ParseInt", OptionalStringParseInt_test)
+}
+
+func OptionalStringTrimSpace_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalString.TrimSpace", t)
+
+	if v := op.OfString(" \t" + TEST_STR + "\n").TrimSpace().Get(); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+	if op.EmptyString().TrimSpace().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func OptionalStringNonBlank_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalString.NonBlank", t)
+
+	if v := op.OfString(" a ").NonBlank().Get(); v != " a " {
+		t.Errorf("Expected `%v`, got `%v`", " a ", v)
+	}
+	if op.OfString(" \t\n").NonBlank().IsPresent() || op.OfString("").NonBlank().IsPresent() {
+		t.Error("Blank string should be empty.")
+	}
+}
+
+func OptionalStringParseInt_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalString.ParseInt", t)
+
+	if v := op.OfString(" " + TEST_STR).TrimSpace().ParseInt().OrElse(0); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.OfString("12a").ParseInt().IsPresent() || op.EmptyString().ParseInt().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func Test_OptionalBool(t *testing.T) {
+	t.Run("Not", OptionalBoolNot_test)
+}
+
+func OptionalBoolNot_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalBool.Not", t)
+
+	if v := op.OfBool(true).Not().OrElsePanic("Value should be present."); v {
+		t.Error("Expected `false`, got `true`")
+	}
+	if op.EmptyBool().Not().IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
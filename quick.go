@@ -0,0 +1,31 @@
+package optional
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Implements quick.Generator from testing/quick, so *Optional arguments of
+// properties checked by quick.Check receive random optionals.
+//
+// One Optional in five is empty. The others hold an int, a string, a float64
+// or a bool, each equally likely, whose magnitude or length is bounded by
+// size.
+func (o *Optional) Generate(r *rand.Rand, size int) reflect.Value {
+	g := &Optional{}
+	switch r.Intn(5) {
+	case 1:
+		g.set(r.Intn(2*size+1)-size, true)
+	case 2:
+		rs := make([]rune, r.Intn(size+1))
+		for i := range rs {
+			rs[i] = rune(r.Intn(0x10000))
+		}
+		g.set(string(rs), true)
+	case 3:
+		g.set((2*r.Float64()-1)*float64(size), true)
+	case 4:
+		g.set(r.Intn(2) == 1, true)
+	}
+	return reflect.ValueOf(g)
+}
@@ -0,0 +1,54 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func Test_Generate(t *testing.T) {
+	t.Run("Generate", Generate_test)
+	t.Run("quick.Check", GenerateQuickCheck_test)
+}
+
+func Generate_test(t *testing.T) {
+	defer shouldNotPanic("optional.Generate", t)
+
+	r := rand.New(rand.NewSource(1))
+	kinds := map[string]int{}
+	for i := 0; i < 500; i++ {
+		o := new(op.Optional).Generate(r, 10).Interface().(*op.Optional)
+		switch v := o.Get().(type) {
+		case nil:
+			kinds["empty"]++
+		case int:
+			if v < -10 || v > 10 {
+				t.Errorf("Value `%v` out of bounds for size 10", v)
+			}
+			kinds["int"]++
+		case string:
+			kinds["string"]++
+		case float64:
+			kinds["float64"]++
+		case bool:
+			kinds["bool"]++
+		default:
+			t.Errorf("Unexpected value type `%T`", v)
+		}
+	}
+	if len(kinds) != 5 {
+		t.Errorf("Expected every kind of optional to be generated, got `%v`", kinds)
+	}
+}
+
+func GenerateQuickCheck_test(t *testing.T) {
+	defer shouldNotPanic("optional.Generate", t)
+
+	orElse := func(o *op.Optional) bool {
+		return o.IsPresent() == (o.OrElse(nil) != nil)
+	}
+	if err := quick.Check(orElse, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,36 @@
+package optional
+
+import "log/slog"
+
+// The value logged in place of an empty Optional.
+//
+// Defaults to the string "<empty>". It may be replaced at program start to
+// change how empty optionals appear in structured logs.
+var EmptyLogValue = slog.StringValue("<empty>")
+
+// Implements slog.LogValuer.
+//
+// If a value is present, it is logged as the contained value, otherwise
+// EmptyLogValue is logged. A nil Optional is logged as empty.
+func (o *Optional) LogValue() slog.Value {
+	if o != nil && o.present {
+		return slog.AnyValue(o.t)
+	}
+	return EmptyLogValue
+}
+
+// Returns an slog.Attr for the given key and Optional.
+//
+// Empty optionals are logged as EmptyLogValue.
+func Attr(key string, o *Optional) slog.Attr {
+	return slog.Attr{Key: key, Value: o.LogValue()}
+}
+
+// Returns an slog.Attr for the given key and Optional, if a value is present,
+// otherwise returns the zero slog.Attr, which handlers omit from the output.
+func AttrOmitEmpty(key string, o *Optional) slog.Attr {
+	if o == nil || !o.present {
+		return slog.Attr{}
+	}
+	return Attr(key, o)
+}
@@ -0,0 +1,91 @@
+package optional_test
+
+import (
+	"bytes"
+	op "github.com/MercuryThePlanet/optional"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func logLine(args ...any) string {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("msg", args...)
+	return strings.TrimSpace(buf.String())
+}
+
+func Test_LogValue(t *testing.T) {
+	t.Run("LogValue", LogValue_test)
+	t.Run("LogValue empty", LogValueEmpty_test)
+	t.Run("LogValue nil", LogValueNil_test)
+	t.Run("LogValue custom marker", LogValueMarker_test)
+}
+
+func LogValue_test(t *testing.T) {
+	defer shouldNotPanic("optional.LogValue", t)
+
+	if v := logLine("user_id", op.Of(TEST_INT)); v != "msg=msg user_id=123" {
+		t.Errorf("Expected `%v`, got `%v`", "msg=msg user_id=123", v)
+	}
+}
+
+func LogValueEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.LogValue", t)
+
+	if v := logLine("user_id", op.Empty()); v != "msg=msg user_id=<empty>" {
+		t.Errorf("Expected `%v`, got `%v`", "msg=msg user_id=<empty>", v)
+	}
+}
+
+func LogValueNil_test(t *testing.T) {
+	defer shouldNotPanic("optional.LogValue", t)
+
+	var o *op.Optional
+	if v := o.LogValue(); !v.Equal(op.EmptyLogValue) {
+		t.Errorf("Expected `%v`, got `%v`", op.EmptyLogValue, v)
+	}
+}
+
+func LogValueMarker_test(t *testing.T) {
+	defer shouldNotPanic("optional.LogValue", t)
+
+	old := op.EmptyLogValue
+	defer func() { op.EmptyLogValue = old }()
+	op.EmptyLogValue = slog.StringValue("-")
+
+	if v := logLine("user_id", op.Empty()); v != "msg=msg user_id=-" {
+		t.Errorf("Expected `%v`, got `%v`", "msg=msg user_id=-", v)
+	}
+}
+
+func Test_Attr(t *testing.T) {
+	t.Run("Attr", Attr_test)
+	t.Run("AttrOmitEmpty", AttrOmitEmpty_test)
+}
+
+func Attr_test(t *testing.T) {
+	defer shouldNotPanic("optional.Attr", t)
+
+	line := logLine(op.Attr("a", op.Of(TEST_STR)), op.Attr("b", op.Empty()))
+	if expected := `msg=msg a=123 b=<empty>`; line != expected {
+		t.Errorf("Expected `%v`, got `%v`", expected, line)
+	}
+}
+
+func AttrOmitEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.AttrOmitEmpty", t)
+
+	line := logLine(op.AttrOmitEmpty("a", op.Of(TEST_STR)),
+		op.AttrOmitEmpty("b", op.Empty()))
+	if expected := `msg=msg a=123`; line != expected {
+		t.Errorf("Expected `%v`, got `%v`", expected, line)
+	}
+}
@@ -0,0 +1,164 @@
+package optional
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// A Parser function signature.
+//
+// Takes the textual form of a value and returns the parsed value.
+type Parser func(string) (T, error)
+
+var parsers = struct {
+	sync.RWMutex
+	m map[reflect.Type]Parser
+}{m: map[reflect.Type]Parser{
+	reflect.TypeOf(time.Duration(0)): func(s string) (T, error) {
+		return time.ParseDuration(s)
+	},
+}}
+
+// Registers a Parser used by UnmarshalText for values of the same type as t.
+//
+// Registered parsers take precedence over encoding.TextUnmarshaler and the
+// builtin kinds. time.Duration is registered by default.
+func RegisterParser(t T, p Parser) {
+	parsers.Lock()
+	defer parsers.Unlock()
+	parsers.m[reflect.TypeOf(t)] = p
+}
+
+// Returns an empty Optional whose decoded values will have the same type as t.
+//
+// UnmarshalText, Scan and the other decoders need to know which type to
+// produce. A present Optional decodes into the type of its current value; an
+// empty Optional created by any other constructor decodes into a string.
+func EmptyOf(t T) *Optional {
+	return &Optional{typ: reflect.TypeOf(t)}
+}
+
+// Implements encoding.TextMarshaler.
+//
+// An empty Optional is marshaled as an empty string. A present value is
+// marshaled with its own MarshalText method if it has one, otherwise it is
+// formatted with fmt.
+func (o *Optional) MarshalText() ([]byte, error) {
+	if !o.present {
+		return []byte{}, nil
+	}
+	if m, ok := o.t.(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	return []byte(fmt.Sprint(o.t)), nil
+}
+
+// Implements encoding.TextUnmarshaler.
+//
+// An empty text empties the Optional but keeps its type. Any other text is
+// parsed into the Optional's type (see EmptyOf) using, in order, a registered
+// Parser, the type's UnmarshalText method or the parser for its builtin kind.
+func (o *Optional) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		if o.typ == nil && o.present {
+			o.typ = reflect.TypeOf(o.t)
+		}
+		o.set(nil, false)
+		return nil
+	}
+	t, err := parseText(string(text), o.elemType())
+	if err != nil {
+		return err
+	}
+	o.set(t, true)
+	return nil
+}
+
+// Implements fmt.Scanner.
+//
+// Reads a single space-delimited token and decodes it as UnmarshalText does.
+func (o *Optional) Scan(state fmt.ScanState, verb rune) error {
+	token, err := state.Token(true, nil)
+	if err != nil {
+		return err
+	}
+	return o.UnmarshalText(token)
+}
+
+// Returns the type values are decoded into.
+func (o *Optional) elemType() reflect.Type {
+	if o.typ != nil {
+		return o.typ
+	}
+	if o.present {
+		return reflect.TypeOf(o.t)
+	}
+	return reflect.TypeOf("")
+}
+
+func parseText(s string, typ reflect.Type) (T, error) {
+	parsers.RLock()
+	p, ok := parsers.m[typ]
+	parsers.RUnlock()
+	if ok {
+		return p(s)
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		if u, ok := reflect.New(typ.Elem()).Interface().(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(s)); err != nil {
+				return nil, err
+			}
+			return u, nil
+		}
+	} else if u, ok := reflect.New(typ).Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(s)); err != nil {
+			return nil, err
+		}
+		return reflect.ValueOf(u).Elem().Interface(), nil
+	}
+
+	v := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 0, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 0, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetFloat(f)
+	case reflect.Complex64, reflect.Complex128:
+		c, err := strconv.ParseComplex(s, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		v.SetComplex(c)
+	default:
+		return nil, fmt.Errorf("optional: no parser registered for type %v", typ)
+	}
+	return v.Interface(), nil
+}
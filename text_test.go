@@ -0,0 +1,230 @@
+package optional_test
+
+import (
+	"flag"
+	"fmt"
+	op "github.com/MercuryThePlanet/optional"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Level int
+
+func Test_MarshalText(t *testing.T) {
+	t.Run("MarshalText", MarshalText_test)
+	t.Run("MarshalText empty", MarshalTextEmpty_test)
+	t.Run("MarshalText TextMarshaler", MarshalTextMarshaler_test)
+}
+
+func MarshalText_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalText", t)
+
+	b, err := op.Of(TEST_INT).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, string(b))
+	}
+}
+
+func MarshalTextEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalText", t)
+
+	b, err := op.Empty().MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 0 {
+		t.Errorf("Expected empty text, got `%v`", string(b))
+	}
+}
+
+func MarshalTextMarshaler_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalText", t)
+
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	b, err := op.Of(ts).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "2020-01-02T03:04:05Z" {
+		t.Errorf("Expected `%v`, got `%v`", "2020-01-02T03:04:05Z", string(b))
+	}
+}
+
+func Test_UnmarshalText(t *testing.T) {
+	t.Run("UnmarshalText builtin kinds", UnmarshalTextKinds_test)
+	t.Run("UnmarshalText empty", UnmarshalTextEmpty_test)
+	t.Run("UnmarshalText untyped", UnmarshalTextUntyped_test)
+	t.Run("UnmarshalText present type", UnmarshalTextPresent_test)
+	t.Run("UnmarshalText present, empty, present", UnmarshalTextRepeated_test)
+	t.Run("UnmarshalText TextUnmarshaler", UnmarshalTextUnmarshaler_test)
+	t.Run("UnmarshalText registered parser", UnmarshalTextParser_test)
+	t.Run("UnmarshalText invalid", UnmarshalTextInvalid_test)
+}
+
+func UnmarshalTextKinds_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	cases := []struct {
+		zero     op.T
+		text     string
+		expected op.T
+	}{
+		{"", "abc", "abc"},
+		{false, "true", true},
+		{0, "-12", -12},
+		{int8(0), "0x10", int8(16)},
+		{uint16(0), "65535", uint16(65535)},
+		{float32(0), "1.5", float32(1.5)},
+		{0.0, "2.25", 2.25},
+		{complex128(0), "1+2i", complex(1, 2)},
+		{Level(0), "3", Level(3)},
+		{time.Duration(0), "1m30s", 90 * time.Second},
+	}
+	for _, c := range cases {
+		o := op.EmptyOf(c.zero)
+		if err := o.UnmarshalText([]byte(c.text)); err != nil {
+			t.Errorf("Unexpected error for `%v`: %v", c.text, err)
+		} else if v := o.Get(); v != c.expected {
+			t.Errorf("Expected `%v` (%T), got `%v` (%T)", c.expected,
+				c.expected, v, v)
+		}
+	}
+}
+
+func UnmarshalTextEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	o := op.Of(TEST_INT)
+	if err := o.UnmarshalText([]byte{}); err != nil {
+		t.Fatal(err)
+	}
+	if o.IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+func UnmarshalTextUntyped_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	o := op.Empty()
+	if err := o.UnmarshalText([]byte(TEST_STR)); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := o.Get().(string); !ok || v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, o.Get())
+	}
+}
+
+func UnmarshalTextPresent_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	o := op.Of(TEST_OTHER)
+	if err := o.UnmarshalText([]byte(TEST_STR)); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := o.Get().(int); !ok || v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, o.Get())
+	}
+}
+
+func UnmarshalTextRepeated_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	o := op.Of(TEST_OTHER)
+	if err := o.UnmarshalText(nil); err != nil || o.IsPresent() {
+		t.Fatalf("Expected an empty Optional, got `%v`, %v", o.Get(), err)
+	}
+	if err := o.UnmarshalText([]byte(TEST_STR)); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := o.Get().(int); !ok || v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v` (%T)", TEST_INT, o.Get(), o.Get())
+	}
+}
+
+func UnmarshalTextUnmarshaler_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	o := op.EmptyOf(net.IP{})
+	if err := o.UnmarshalText([]byte("10.0.0.1")); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := o.Get().(net.IP); !ok || !v.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("Expected `%v`, got `%v`", "10.0.0.1", o.Get())
+	}
+
+	o = op.EmptyOf(&time.Time{})
+	if err := o.UnmarshalText([]byte("2020-01-02T03:04:05Z")); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := o.Get().(*time.Time); !ok || v.Year() != 2020 {
+		t.Errorf("Expected `%v`, got `%v`", "2020-01-02T03:04:05Z", o.Get())
+	}
+}
+
+type Upper string
+
+func UnmarshalTextParser_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	op.RegisterParser(Upper(""), func(s string) (op.T, error) {
+		return Upper(strings.ToUpper(s)), nil
+	})
+
+	o := op.EmptyOf(Upper(""))
+	if err := o.UnmarshalText([]byte("abc")); err != nil {
+		t.Fatal(err)
+	}
+	if v := o.Get(); v != Upper("ABC") {
+		t.Errorf("Expected `%v`, got `%v`", "ABC", v)
+	}
+}
+
+func UnmarshalTextInvalid_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	o := op.EmptyOf(0)
+	if err := o.UnmarshalText([]byte("abc")); err == nil {
+		t.Error("Expected a parse error.")
+	}
+	if err := op.EmptyOf(struct{}{}).UnmarshalText([]byte("abc")); err == nil {
+		t.Error("Expected an unsupported type error.")
+	}
+}
+
+func Test_Scan(t *testing.T) {
+	t.Run("Scan", Scan_test)
+	t.Run("Scan flag.TextVar", ScanTextVar_test)
+}
+
+func Scan_test(t *testing.T) {
+	defer shouldNotPanic("optional.Scan", t)
+
+	a, b := op.EmptyOf(0), op.EmptyOf(0.0)
+	if _, err := fmt.Sscan("123 4.5", a, b); err != nil {
+		t.Fatal(err)
+	}
+	if a.Get() != TEST_INT || b.Get() != 4.5 {
+		t.Errorf("Expected `123 4.5`, got `%v %v`", a.Get(), b.Get())
+	}
+}
+
+func ScanTextVar_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalText", t)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	timeout := op.EmptyOf(time.Duration(0))
+	fs.TextVar(timeout, "timeout", op.EmptyOf(time.Duration(0)),
+		"request timeout")
+	if err := fs.Parse([]string{"-timeout", "5s"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := timeout.Get(); v != 5*time.Second {
+		t.Errorf("Expected `%v`, got `%v`", 5*time.Second, v)
+	}
+}
@@ -0,0 +1,98 @@
+package optional
+
+import "time"
+
+// struct OptionalTime is an Option of time.Time with time helpers.
+//
+// The zero time.Time is a valid, present value. Use NonZero to treat it as
+// empty.
+type OptionalTime struct {
+	Option[time.Time]
+}
+
+// Returns an empty OptionalTime instance.
+func EmptyTime() OptionalTime {
+	return OptionalTime{}
+}
+
+// Returns an OptionalTime describing the given value.
+func OfTime(t time.Time) OptionalTime {
+	return OptionalTime{OptionOf(t)}
+}
+
+// Returns an OptionalTime describing the value parsed with the first of the
+// given layouts that accepts it, or an empty OptionalTime if none does. If no
+// layout is given, time.RFC3339 is used.
+func ParseTime(value string, layouts ...string) OptionalTime {
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return OfTime(t)
+		}
+	}
+	return OptionalTime{}
+}
+
+// Returns an Option describing the duration parsed as by time.ParseDuration,
+// or an empty Option if it is invalid.
+func ParseDuration(s string) Option[time.Duration] {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return Option[time.Duration]{}
+	}
+	return OptionOf(d)
+}
+
+// Returns an OptionalTime describing the local time corresponding to the given
+// Unix time in seconds.
+func UnixSeconds(sec int64) OptionalTime {
+	return OfTime(time.Unix(sec, 0))
+}
+
+// If a value is present and is not the zero time, returns the OptionalTime,
+// otherwise returns an empty OptionalTime.
+func (o OptionalTime) NonZero() OptionalTime {
+	if !o.present || o.v.IsZero() {
+		return OptionalTime{}
+	}
+	return o
+}
+
+// If a value is present, returns an OptionalBool describing whether it is
+// before u, otherwise returns an empty OptionalBool.
+func (o OptionalTime) Before(u time.Time) OptionalBool {
+	if !o.present {
+		return OptionalBool{}
+	}
+	return OfBool(o.v.Before(u))
+}
+
+// If a value is present, returns an OptionalBool describing whether it is
+// after u, otherwise returns an empty OptionalBool.
+func (o OptionalTime) After(u time.Time) OptionalBool {
+	if !o.present {
+		return OptionalBool{}
+	}
+	return OfBool(o.v.After(u))
+}
+
+// If a value is present, returns an OptionalTime describing the same instant
+// in the given location, otherwise returns an empty OptionalTime.
+func (o OptionalTime) In(loc *time.Location) OptionalTime {
+	if !o.present {
+		return o
+	}
+	return OfTime(o.v.In(loc))
+}
+
+// If a value is present, returns an OptionalTime describing the value rounded
+// down to a multiple of d, as by time.Time.Truncate, otherwise returns an
+// empty OptionalTime.
+func (o OptionalTime) Truncate(d time.Duration) OptionalTime {
+	if !o.present {
+		return o
+	}
+	return OfTime(o.v.Truncate(d))
+}
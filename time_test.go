@@ -0,0 +1,120 @@
+package optional_test
+
+import (
+	op "github.com/MercuryThePlanet/optional"
+	"testing"
+	"time"
+)
+
+func Test_ParseTime(t *testing.T) {
+	t.Run("ParseTime", ParseTime_test)
+	t.Run("ParseTime layouts", ParseTimeLayouts_test)
+	t.Run("ParseTime invalid", ParseTimeInvalid_test)
+	t.Run("ParseDuration", ParseDuration_test)
+	t.Run("UnixSeconds", UnixSeconds_test)
+}
+
+func ParseTime_test(t *testing.T) {
+	defer shouldNotPanic("optional.ParseTime", t)
+
+	want := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	if v := op.ParseTime("2024-03-01T12:30:00Z").Get(); !v.Equal(want) {
+		t.Errorf("Expected `%v`, got `%v`", want, v)
+	}
+}
+
+func ParseTimeLayouts_test(t *testing.T) {
+	defer shouldNotPanic("optional.ParseTime", t)
+
+	want := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	o := op.ParseTime("01/03/2024", time.RFC3339, time.DateOnly, "02/01/2006")
+	if v := o.Get(); !v.Equal(want) {
+		t.Errorf("Expected `%v`, got `%v`", want, v)
+	}
+}
+
+func ParseTimeInvalid_test(t *testing.T) {
+	defer shouldNotPanic("optional.ParseTime", t)
+
+	if op.ParseTime("yesterday", time.RFC3339, time.DateOnly).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func ParseDuration_test(t *testing.T) {
+	defer shouldNotPanic("optional.ParseDuration", t)
+
+	if v := op.ParseDuration("1m30s").Get(); v != 90*time.Second {
+		t.Errorf("Expected `%v`, got `%v`", 90*time.Second, v)
+	}
+	if op.ParseDuration("90").IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func UnixSeconds_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnixSeconds", t)
+
+	if v := op.UnixSeconds(86400).Get().UTC(); !v.Equal(time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time `%v`", v)
+	}
+}
+
+func Test_OptionalTime(t *testing.T) {
+	t.Run("NonZero", OptionalTimeNonZero_test)
+	t.Run("Before and After", OptionalTimeBeforeAfter_test)
+	t.Run("In", OptionalTimeIn_test)
+	t.Run("Truncate", OptionalTimeTruncate_test)
+}
+
+func OptionalTimeNonZero_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalTime.NonZero", t)
+
+	if !op.OfTime(time.Time{}).IsPresent() {
+		t.Error("Zero time should be present.")
+	}
+	if op.OfTime(time.Time{}).NonZero().IsPresent() {
+		t.Error("Zero time should be empty.")
+	}
+	if !op.UnixSeconds(0).NonZero().IsPresent() {
+		t.Error("Unix epoch should be present.")
+	}
+}
+
+func OptionalTimeBeforeAfter_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalTime", t)
+
+	now := time.Now()
+	o := op.OfTime(now.Add(-time.Hour))
+	if !o.Before(now).OrElse(false) || o.After(now).OrElse(true) {
+		t.Error("Time should be before now.")
+	}
+	if op.EmptyTime().Before(now).IsPresent() || op.EmptyTime().After(now).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func OptionalTimeIn_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalTime.In", t)
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	v := op.ParseTime("2024-03-01T12:30:00Z").In(loc).Get()
+	if v.Location() != loc || v.Hour() != 14 {
+		t.Errorf("Expected 14:30 in `%v`, got `%v`", loc, v)
+	}
+	if op.EmptyTime().In(loc).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func OptionalTimeTruncate_test(t *testing.T) {
+	defer shouldNotPanic("optional.OptionalTime.Truncate", t)
+
+	want := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if v := op.ParseTime("2024-03-01T12:30:00Z").Truncate(time.Hour).Get(); !v.Equal(want) {
+		t.Errorf("Expected `%v`, got `%v`", want, v)
+	}
+	if op.EmptyTime().Truncate(time.Hour).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
@@ -0,0 +1,120 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// A Step records a single combinator call on a traced Optional.
+type Step struct {
+	// The name of the combinator, e.g. "Map" or "Filter".
+	Op string
+	// The file and line of the call site.
+	File string
+	Line int
+	// Whether the Optional held a value after the step.
+	Present bool
+}
+
+// Returns the step formatted as "Op (file:line): present|empty".
+func (s Step) String() string {
+	state := "empty"
+	if s.Present {
+		state = "present"
+	}
+	return fmt.Sprintf("%s (%s:%d): %s", s.Op, filepath.Base(s.File), s.Line,
+		state)
+}
+
+// ErrEmpty is returned by OrElseError when no error is given.
+var ErrEmpty = errors.New("optional is empty")
+
+// An EmptyError is returned by OrElseError. It wraps the given error and
+// carries the reason and trace of the empty Optional, if any.
+type EmptyError struct {
+	Err    error
+	Reason string
+	Trace  []Step
+}
+
+func (e *EmptyError) Error() string {
+	return describe(e.Err.Error(), e.Reason, e.Trace)
+}
+
+func (e *EmptyError) Unwrap() error {
+	return e.Err
+}
+
+// Returns an empty Optional carrying the given reason.
+//
+// The reason is kept until a value becomes present, and is reported by
+// Reason, OrElsePanic and OrElseError.
+func EmptyBecause(reason string) *Optional {
+	return &Optional{reason: reason}
+}
+
+// Returns the reason the Optional is empty, or an empty string if a value is
+// present or no reason was given.
+func (o *Optional) Reason() string {
+	return o.reason
+}
+
+// Enables tracing on the Optional and returns it.
+//
+// Once traced, every Filter, Map, FlatMap and Or call records a Step with the
+// caller's file and line and whether a value was present afterwards. Tracing
+// is off by default since capturing the caller is comparatively expensive.
+func (o *Optional) Traced() *Optional {
+	if !o.traced {
+		o.traced = true
+		o.record("Traced")
+	}
+	return o
+}
+
+// Returns the steps recorded since Traced was called, or nil if the Optional
+// is not traced.
+func (o *Optional) Trace() []Step {
+	return append([]Step(nil), o.trace...)
+}
+
+// If a value is present, returns the value and a nil error, otherwise returns
+// nil and an *EmptyError wrapping err. If err is nil, ErrEmpty is wrapped.
+func (o *Optional) OrElseError(err error) (T, error) {
+	if o.present {
+		return o.t, nil
+	}
+	if err == nil {
+		err = ErrEmpty
+	}
+	return nil, &EmptyError{Err: err, Reason: o.reason, Trace: o.Trace()}
+}
+
+func (o *Optional) record(op string) *Optional {
+	if o.traced {
+		_, file, line, _ := runtime.Caller(2)
+		o.trace = append(o.trace, Step{op, file, line, o.present})
+	}
+	return o
+}
+
+func (o *Optional) describe(msg string) string {
+	return describe(msg, o.reason, o.trace)
+}
+
+func describe(msg, reason string, trace []Step) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	if reason != "" {
+		b.WriteString(": ")
+		b.WriteString(reason)
+	}
+	for _, s := range trace {
+		b.WriteString("\n\t")
+		b.WriteString(s.String())
+	}
+	return b.String()
+}
@@ -0,0 +1,172 @@
+package optional_test
+
+import (
+	"errors"
+	op "github.com/MercuryThePlanet/optional"
+	"strings"
+	"testing"
+)
+
+func Test_Trace(t *testing.T) {
+	t.Run("Trace", Trace_test)
+	t.Run("Trace not traced", TraceNotTraced_test)
+	t.Run("Trace through FlatMap", TraceFlatMap_test)
+	t.Run("Trace in OrElsePanic", TraceOrElsePanic_test)
+}
+
+func Trace_test(t *testing.T) {
+	defer shouldNotPanic("optional.Trace", t)
+
+	o := op.OfNilable(TEST_INT).Traced().Map(func(v op.T) op.T {
+		return v.(int) * 2
+	}).Filter(func(v op.T) bool {
+		return v.(int) < 0
+	}).Map(func(v op.T) op.T {
+		return v
+	})
+
+	steps := o.Trace()
+	expected := []struct {
+		op      string
+		present bool
+	}{{"Traced", true}, {"Map", true}, {"Filter", false}, {"Map", false}}
+	if len(steps) != len(expected) {
+		t.Fatalf("Expected %d steps, got %v", len(expected), steps)
+	}
+	for i, e := range expected {
+		if steps[i].Op != e.op || steps[i].Present != e.present {
+			t.Errorf("Expected step %d to be `%v %v`, got `%v`", i, e.op,
+				e.present, steps[i])
+		}
+		if !strings.HasSuffix(steps[i].File, "trace_test.go") {
+			t.Errorf("Expected caller file trace_test.go, got `%v`",
+				steps[i].File)
+		}
+	}
+}
+
+func TraceNotTraced_test(t *testing.T) {
+	defer shouldNotPanic("optional.Trace", t)
+
+	o := op.Of(TEST_INT).Map(func(v op.T) op.T { return nil })
+	if steps := o.Trace(); steps != nil {
+		t.Errorf("Expected no steps, got `%v`", steps)
+	}
+}
+
+func TraceFlatMap_test(t *testing.T) {
+	defer shouldNotPanic("optional.Trace", t)
+
+	o := op.Of(TEST_INT).Traced().FlatMap(func(v op.T) op.T {
+		return op.Of(v)
+	}).(*op.Optional).Filter(func(v op.T) bool { return false })
+
+	steps := o.Trace()
+	if len(steps) != 3 || steps[1].Op != "FlatMap" || steps[2].Op != "Filter" {
+		t.Errorf("Expected Traced, FlatMap and Filter steps, got `%v`", steps)
+	}
+}
+
+func TraceOrElsePanic_test(t *testing.T) {
+	defer func() {
+		r := recover()
+		msg, ok := r.(string)
+		if !ok {
+			t.Fatalf("Expected a string panic, got `%v`", r)
+		}
+		if !strings.HasPrefix(msg, TEST_PANIC) ||
+			!strings.Contains(msg, "Filter (trace_test.go:") {
+			t.Errorf("Panic message should contain the trace, got `%v`", msg)
+		}
+	}()
+
+	op.Of(TEST_INT).Traced().Filter(func(v op.T) bool {
+		return false
+	}).OrElsePanic(TEST_PANIC)
+}
+
+func Test_EmptyBecause(t *testing.T) {
+	t.Run("EmptyBecause", EmptyBecause_test)
+	t.Run("EmptyBecause cleared by Or", EmptyBecauseOr_test)
+	t.Run("EmptyBecause in OrElsePanic", EmptyBecauseOrElsePanic_test)
+}
+
+func EmptyBecause_test(t *testing.T) {
+	defer shouldNotPanic("optional.EmptyBecause", t)
+
+	o := op.EmptyBecause("user not found").Map(func(v op.T) op.T {
+		return v
+	})
+	if o.IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+	if r := o.Reason(); r != "user not found" {
+		t.Errorf("Expected `%v`, got `%v`", "user not found", r)
+	}
+}
+
+func EmptyBecauseOr_test(t *testing.T) {
+	defer shouldNotPanic("optional.EmptyBecause", t)
+
+	o := op.EmptyBecause("user not found").Or(func(ts op.Ts) op.T {
+		return TEST_INT
+	})
+	if r := o.Reason(); r != "" {
+		t.Errorf("Reason should be cleared, got `%v`", r)
+	}
+}
+
+func EmptyBecauseOrElsePanic_test(t *testing.T) {
+	defer func() {
+		if r := recover(); r != TEST_PANIC+": user not found" {
+			t.Errorf("Expected `%v`, got `%v`", TEST_PANIC+": user not found", r)
+		}
+	}()
+
+	op.EmptyBecause("user not found").OrElsePanic(TEST_PANIC)
+}
+
+func Test_OrElseError(t *testing.T) {
+	t.Run("OrElseError", OrElseError_test)
+	t.Run("OrElseError empty", OrElseErrorEmpty_test)
+	t.Run("OrElseError nil error", OrElseErrorNil_test)
+}
+
+func OrElseError_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrElseError", t)
+
+	v, err := op.Of(TEST_INT).OrElseError(errors.New(TEST_PANIC))
+	if err != nil {
+		t.Errorf("Expected no error, got `%v`", err)
+	} else if v.(int) != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+}
+
+func OrElseErrorEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrElseError", t)
+
+	sentinel := errors.New(TEST_PANIC)
+	v, err := op.EmptyBecause("user not found").OrElseError(sentinel)
+	if v != nil {
+		t.Errorf("Expected nil, got `%v`", v)
+	}
+	if !errors.Is(err, sentinel) {
+		t.Errorf("Error should wrap the sentinel, got `%v`", err)
+	}
+	var emptyErr *op.EmptyError
+	if !errors.As(err, &emptyErr) || emptyErr.Reason != "user not found" {
+		t.Errorf("Error should be an EmptyError with a reason, got `%v`", err)
+	}
+	if msg := err.Error(); msg != TEST_PANIC+": user not found" {
+		t.Errorf("Expected `%v`, got `%v`", TEST_PANIC+": user not found", msg)
+	}
+}
+
+func OrElseErrorNil_test(t *testing.T) {
+	defer shouldNotPanic("optional.OrElseError", t)
+
+	if _, err := op.Empty().OrElseError(nil); !errors.Is(err, op.ErrEmpty) {
+		t.Errorf("Error should wrap ErrEmpty, got `%v`", err)
+	}
+}
@@ -0,0 +1,174 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrRequired is reported for a required optional that is empty.
+	ErrRequired = errors.New("value is required")
+	// ErrInvalid is reported for a present value rejected by a predicate.
+	ErrInvalid = errors.New("value is invalid")
+)
+
+// struct FieldError reports why a field failed validation.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return "optional: " + e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+var predicates = struct {
+	sync.RWMutex
+	m map[string]Predicate
+}{m: map[string]Predicate{}}
+
+// Registers a Predicate that Validate runs for fields whose optional tag names
+// it.
+func RegisterPredicate(name string, p Predicate) {
+	predicates.Lock()
+	defer predicates.Unlock()
+	predicates.m[name] = p
+}
+
+// Validates the optional fields of the struct pointed to by v.
+//
+// Fields are selected by an optional tag holding a comma separated list of
+// rules. The rule "required" rejects an empty field; any other rule names a
+// Predicate registered with RegisterPredicate, which must accept a present
+// value:
+//
+//	type Request struct {
+//		Name  optional.OptionalString `optional:"required,nonblank"`
+//		Limit *optional.Optional      `optional:"positive"`
+//	}
+//
+// A field may be an Optional, an *Optional (nil is empty), or any other type
+// with IsPresent and Get methods, such as Option. Every failing field is
+// reported as a *FieldError, joined with errors.Join.
+func Validate(v T) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optional: Validate takes a struct pointer, got %T", v)
+	}
+	rv = rv.Elem()
+
+	var errs []error
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		tag, ok := field.Tag.Lookup("optional")
+		if !ok || tag == "" || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		present, value, ok := inspect(rv.Field(i))
+		if !ok {
+			errs = append(errs, &FieldError{field.Name,
+				fmt.Errorf("%s is not an optional", field.Type)})
+			continue
+		}
+		for _, rule := range strings.Split(tag, ",") {
+			if err := check(rule, present, value); err != nil {
+				errs = append(errs, &FieldError{field.Name, err})
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func check(rule string, present bool, value T) error {
+	rule = strings.TrimSpace(rule)
+	if rule == "required" {
+		if !present {
+			return ErrRequired
+		}
+		return nil
+	}
+
+	predicates.RLock()
+	p, ok := predicates.m[rule]
+	predicates.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown predicate %q", rule)
+	}
+	if present && !p(value) {
+		return fmt.Errorf("%w: %s", ErrInvalid, rule)
+	}
+	return nil
+}
+
+// Reports whether the field holds a present value, and the value, using its
+// IsPresent and Get methods. ok is false if the field has no such methods.
+func inspect(v reflect.Value) (present bool, value T, ok bool) {
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		v = v.Addr()
+	}
+	p, ok := v.Interface().(interface{ IsPresent() bool })
+	get := v.MethodByName("Get")
+	if !ok || !get.IsValid() || get.Type().NumIn() != 0 || get.Type().NumOut() != 1 {
+		return false, nil, false
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return false, nil, true
+	}
+	if !p.IsPresent() {
+		return false, nil, true
+	}
+	return true, get.Call(nil)[0].Interface(), true
+}
+
+// struct Validator collects validation errors for optionals that are not
+// struct fields. The zero Validator is ready to use.
+type Validator struct {
+	errs []error
+}
+
+// Returns a Validator that has checked that o is present and its value is
+// accepted by every given predicate.
+func Require(name string, o *Optional, preds ...Predicate) *Validator {
+	return new(Validator).Require(name, o, preds...)
+}
+
+// Checks that o is present and its value is accepted by every given
+// predicate. A nil o is empty.
+func (v *Validator) Require(name string, o *Optional, preds ...Predicate) *Validator {
+	if !isPresent(o) {
+		v.errs = append(v.errs, &FieldError{name, ErrRequired})
+		return v
+	}
+	return v.Check(name, o, preds...)
+}
+
+// Checks that the value of o, if present, is accepted by every given
+// predicate.
+func (v *Validator) Check(name string, o *Optional, preds ...Predicate) *Validator {
+	if !isPresent(o) {
+		return v
+	}
+	for i, p := range preds {
+		if !p(o.t) {
+			v.errs = append(v.errs, &FieldError{name,
+				fmt.Errorf("%w: predicate %d", ErrInvalid, i)})
+			break
+		}
+	}
+	return v
+}
+
+// Returns the collected errors joined with errors.Join, or nil if every check
+// passed.
+func (v *Validator) Err() error {
+	return errors.Join(v.errs...)
+}
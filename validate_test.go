@@ -0,0 +1,138 @@
+package optional_test
+
+import (
+	"errors"
+	op "github.com/MercuryThePlanet/optional"
+	"strings"
+	"testing"
+)
+
+func init() {
+	op.RegisterPredicate("positive", func(t op.T) bool {
+		return t.(int) > 0
+	})
+	op.RegisterPredicate("nonblank", func(t op.T) bool {
+		return strings.TrimSpace(t.(string)) != ""
+	})
+}
+
+type Request struct {
+	Name    op.OptionalString `optional:"required,nonblank"`
+	Limit   *op.Optional      `optional:"positive"`
+	Owner   op.Optional       `optional:"required"`
+	Tags    op.Option[[]string]
+	Comment op.Option[string] `optional:"-"`
+}
+
+func Test_Validate(t *testing.T) {
+	t.Run("Validate", Validate_test)
+	t.Run("Validate missing", ValidateMissing_test)
+	t.Run("Validate invalid", ValidateInvalid_test)
+	t.Run("Validate not a struct pointer", ValidateNotStruct_test)
+	t.Run("Validate unknown predicate", ValidateUnknownPredicate_test)
+	t.Run("Validate not an optional", ValidateNotOptional_test)
+}
+
+func Validate_test(t *testing.T) {
+	defer shouldNotPanic("optional.Validate", t)
+
+	r := Request{Name: op.OfString("a"), Limit: op.Of(10), Owner: *op.Of("b")}
+	if err := op.Validate(&r); err != nil {
+		t.Errorf("Expected no error, got `%v`", err)
+	}
+}
+
+func ValidateMissing_test(t *testing.T) {
+	defer shouldNotPanic("optional.Validate", t)
+
+	err := op.Validate(&Request{})
+	if !errors.Is(err, op.ErrRequired) {
+		t.Fatalf("Expected ErrRequired, got `%v`", err)
+	}
+	want := "optional: Name: value is required\noptional: Owner: value is required"
+	if err.Error() != want {
+		t.Errorf("Expected `%v`, got `%v`", want, err)
+	}
+}
+
+func ValidateInvalid_test(t *testing.T) {
+	defer shouldNotPanic("optional.Validate", t)
+
+	r := Request{Name: op.OfString(" "), Limit: op.Of(-1), Owner: *op.Of("b")}
+	err := op.Validate(&r)
+	if !errors.Is(err, op.ErrInvalid) {
+		t.Fatalf("Expected ErrInvalid, got `%v`", err)
+	}
+	want := "optional: Name: value is invalid: nonblank\noptional: Limit: value is invalid: positive"
+	if err.Error() != want {
+		t.Errorf("Expected `%v`, got `%v`", want, err)
+	}
+
+	var fe *op.FieldError
+	if !errors.As(err, &fe) || fe.Field != "Name" {
+		t.Errorf("Expected a FieldError for Name, got `%v`", fe)
+	}
+}
+
+func ValidateNotStruct_test(t *testing.T) {
+	defer shouldNotPanic("optional.Validate", t)
+
+	if err := op.Validate(Request{}); err == nil {
+		t.Error("Expected an error for a struct value.")
+	}
+}
+
+func ValidateUnknownPredicate_test(t *testing.T) {
+	defer shouldNotPanic("optional.Validate", t)
+
+	var r struct {
+		V op.Optional `optional:"unknown"`
+	}
+	if err := op.Validate(&r); err == nil || !strings.Contains(err.Error(), `unknown predicate "unknown"`) {
+		t.Errorf("Expected an unknown predicate error, got `%v`", err)
+	}
+}
+
+func ValidateNotOptional_test(t *testing.T) {
+	defer shouldNotPanic("optional.Validate", t)
+
+	var r struct {
+		V string `optional:"required"`
+	}
+	if err := op.Validate(&r); err == nil || !strings.Contains(err.Error(), "not an optional") {
+		t.Errorf("Expected a not an optional error, got `%v`", err)
+	}
+}
+
+func Test_Require(t *testing.T) {
+	t.Run("Require", Require_test)
+	t.Run("Require collects errors", RequireCollects_test)
+}
+
+func Require_test(t *testing.T) {
+	defer shouldNotPanic("optional.Require", t)
+
+	positive := func(t op.T) bool { return t.(int) > 0 }
+	err := op.Require("limit", op.Of(TEST_INT), positive).
+		Check("offset", op.Empty(), positive).
+		Err()
+	if err != nil {
+		t.Errorf("Expected no error, got `%v`", err)
+	}
+}
+
+func RequireCollects_test(t *testing.T) {
+	defer shouldNotPanic("optional.Require", t)
+
+	positive := func(t op.T) bool { return t.(int) > 0 }
+	err := op.Require("limit", nil).
+		Require("page", op.Of(-1), positive).
+		Check("offset", op.Of(-1), positive).
+		Err()
+	want := "optional: limit: value is required\n" +
+		"optional: page: value is invalid: predicate 0\n" +
+		"optional: offset: value is invalid: predicate 0"
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected `%v`, got `%v`", want, err)
+	}
+}
@@ -0,0 +1,144 @@
+package optional
+
+// struct Value is an untyped optional container held by value.
+//
+// A Value has the API of Optional, but its combinators return a new Value
+// rather than mutating the receiver and the zero Value is empty, so Values
+// are never heap allocated themselves. The payload is still stored as a T, so
+// values that do not fit in an interface are boxed; Option stores them
+// unboxed.
+type Value struct {
+	t       T
+	present bool
+}
+
+// Returns an empty Value instance.
+func ValueEmpty() Value {
+	return Value{}
+}
+
+// Returns a Value describing the given non-nil value.
+func ValueOf(t T) Value {
+	if t != nil {
+		return Value{t: t, present: true}
+	}
+	panic("optional.ValueOf takes a non-nil value. Use ValueOfNilable for potentially nil values.")
+}
+
+// Returns a Value describing the given value, if non-nil, otherwise returns
+// an empty Value.
+func ValueOfNilable(t T) Value {
+	return Value{t: t, present: t != nil}
+}
+
+// If the error is nil, returns a Value describing the given value, if
+// non-nil, otherwise returns an empty Value.
+func ValueOfErrorable(t T, err error) Value {
+	if err != nil {
+		return Value{}
+	}
+	return ValueOfNilable(t)
+}
+
+// Returns a Value describing the value of the Optional, if present, otherwise
+// returns an empty Value.
+func ValueFrom(o *Optional) Value {
+	return Value{t: o.Get(), present: o.present}
+}
+
+// Returns an Optional describing the value, if present, otherwise returns an
+// empty Optional.
+func (o Value) Optional() *Optional {
+	return &Optional{t: o.t, present: o.present}
+}
+
+// If a value is present and matches the given predicate, returns the Value,
+// otherwise returns an empty Value.
+func (o Value) Filter(f Predicate) Value {
+	if o.present && f(o.t) {
+		return o
+	}
+	return Value{}
+}
+
+// If a value is present, returns a Value describing the result of applying
+// the given mapping function to the value, if non-nil, otherwise returns an
+// empty Value.
+func (o Value) Map(f Mapper) Value {
+	if !o.present {
+		return Value{}
+	}
+	return ValueOfNilable(f(o.t))
+}
+
+// If a value is present, returns the result of applying the given
+// Value-bearing mapping function to the value, otherwise returns an empty
+// Value.
+func (o Value) FlatMap(f func(T) Value) Value {
+	if !o.present {
+		return Value{}
+	}
+	return f(o.t)
+}
+
+// If a value is present, returns the Value, otherwise returns a Value
+// describing the value produced by the supplying function, if non-nil.
+func (o Value) Or(f Supplier, ts ...T) Value {
+	if o.present {
+		return o
+	}
+	return ValueOfNilable(f(ts))
+}
+
+// If a value is present, returns the value, otherwise returns nil.
+func (o Value) Get() T {
+	return o.t
+}
+
+// If a value is present, performs the given action with the value, otherwise
+// does nothing.
+func (o Value) IfPresent(f Consumer) {
+	if o.present {
+		f(o.t)
+	}
+}
+
+// If a value is present, performs the given action with the value, otherwise
+// performs the given runnable action.
+func (o Value) IfPresentOrElse(f Consumer, other Runnable) {
+	if o.present {
+		f(o.t)
+	} else {
+		other()
+	}
+}
+
+// If a value is present, returns true, otherwise false.
+func (o Value) IsPresent() bool {
+	return o.present
+}
+
+// If a value is present, returns the value, otherwise returns other.
+func (o Value) OrElse(other T) T {
+	if o.present {
+		return o.t
+	}
+	return other
+}
+
+// If a value is present, returns the value, otherwise returns the result
+// produced by the supplying function.
+func (o Value) OrElseGet(f Supplier, ts ...T) T {
+	if o.present {
+		return o.t
+	}
+	return f(ts)
+}
+
+// If a value is present, returns the value, otherwise panics.
+func (o Value) OrElsePanic(p string) T {
+	if o.present {
+		return o.t
+	}
+	panic(p)
+}
@@ -0,0 +1,193 @@
+package optional_test
+
+import (
+	"errors"
+	op "github.com/MercuryThePlanet/optional"
+	"strconv"
+	"testing"
+)
+
+func Test_Value(t *testing.T) {
+	t.Run("ValueEmpty", ValueEmpty_test)
+	t.Run("ValueOf", ValueOf_test)
+	t.Run("ValueOf nil", ValueOfNil_test)
+	t.Run("ValueOfNilable", ValueOfNilable_test)
+	t.Run("ValueOfErrorable", ValueOfErrorable_test)
+	t.Run("ValueFrom and Optional", ValueOptional_test)
+	t.Run("Value Map and Filter", ValueMapFilter_test)
+	t.Run("Value FlatMap", ValueFlatMap_test)
+	t.Run("Value Or", ValueOr_test)
+	t.Run("Value IfPresent", ValueIfPresent_test)
+	t.Run("Value OrElse", ValueOrElse_test)
+	t.Run("Value OrElsePanic", ValueOrElsePanic_test)
+}
+
+func ValueEmpty_test(t *testing.T) {
+	defer shouldNotPanic("optional.ValueEmpty", t)
+
+	var zero op.Value
+	if op.ValueEmpty().IsPresent() || zero.IsPresent() {
+		t.Error("Empty value should have no value")
+	}
+	if op.ValueEmpty() != zero || op.ValueEmpty().Get() != nil {
+		t.Error("Empty value should be the zero Value.")
+	}
+}
+
+func ValueOf_test(t *testing.T) {
+	defer shouldNotPanic("optional.ValueOf", t)
+
+	o := op.ValueOf(TEST_STR)
+	if !o.IsPresent() {
+		t.Error("Value should be present.")
+	} else if v := o.Get().(string); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+}
+
+func ValueOfNil_test(t *testing.T) {
+	defer shouldPanic("optional.ValueOf with nil value", t)
+
+	op.ValueOf(nil)
+	t.Fatal("this code should not be reachable.")
+}
+
+func ValueOfNilable_test(t *testing.T) {
+	defer shouldNotPanic("optional.ValueOfNilable", t)
+
+	if op.ValueOfNilable(nil).IsPresent() {
+		t.Error("Nil should be empty.")
+	}
+	if !op.ValueOfNilable(0).IsPresent() {
+		t.Error("Zero int should be present.")
+	}
+}
+
+func ValueOfErrorable_test(t *testing.T) {
+	defer shouldNotPanic("optional.ValueOfErrorable", t)
+
+	if v := op.ValueOfErrorable(strconv.Atoi(TEST_STR)).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.ValueOfErrorable(TEST_INT, errors.New("Test")).IsPresent() {
+		t.Error("Value with an error should be empty.")
+	}
+}
+
+func ValueOptional_test(t *testing.T) {
+	defer shouldNotPanic("optional.ValueFrom", t)
+
+	if v := op.ValueFrom(op.Of(TEST_INT)).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.ValueFrom(op.Empty()).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	if v := op.ValueOf(TEST_STR).Optional().Get(); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+	if op.ValueEmpty().Optional().IsPresent() {
+		t.Error("Optional should not be present.")
+	}
+}
+
+func ValueMapFilter_test(t *testing.T) {
+	defer shouldNotPanic("optional.Value.Map", t)
+
+	o := op.ValueOf(TEST_STR)
+	mapped := o.Map(func(t op.T) op.T {
+		n, _ := strconv.Atoi(t.(string))
+		return n
+	}).Filter(func(t op.T) bool {
+		return t.(int) == TEST_INT
+	})
+	if v := mapped.Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if v := o.Get(); v != TEST_STR {
+		t.Errorf("Receiver should be unchanged, got `%v`", v)
+	}
+	if o.Map(func(op.T) op.T { return nil }).IsPresent() {
+		t.Error("Mapping to nil should be empty.")
+	}
+	if o.Filter(func(op.T) bool { return false }).IsPresent() {
+		t.Error("Filtered value should be empty.")
+	}
+	if op.ValueEmpty().Filter(func(op.T) bool { return true }).IsPresent() {
+		t.Error("Empty value should stay empty.")
+	}
+}
+
+func ValueFlatMap_test(t *testing.T) {
+	defer shouldNotPanic("optional.Value.FlatMap", t)
+
+	parse := func(t op.T) op.Value {
+		return op.ValueOfErrorable(strconv.Atoi(t.(string)))
+	}
+	if v := op.ValueOf(TEST_STR).FlatMap(parse).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if op.ValueOf("x").FlatMap(parse).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+	if op.ValueEmpty().FlatMap(parse).IsPresent() {
+		t.Error("Value should not be present.")
+	}
+}
+
+func ValueOr_test(t *testing.T) {
+	defer shouldNotPanic("optional.Value.Or", t)
+
+	other := func(op.Ts) op.T { return TEST_OTHER }
+	if v := op.ValueOf(TEST_INT).Or(other).Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if v := op.ValueEmpty().Or(other).Get(); v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+}
+
+func ValueIfPresent_test(t *testing.T) {
+	defer shouldNotPanic("optional.Value.IfPresent", t)
+
+	var got op.T
+	op.ValueOf(TEST_INT).IfPresent(func(t op.T) { got = t })
+	if got != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, got)
+	}
+	op.ValueEmpty().IfPresent(func(op.T) { t.Error("Should not be called.") })
+
+	ran := false
+	op.ValueEmpty().IfPresentOrElse(func(op.T) {
+		t.Error("Should not be called.")
+	}, func() { ran = true })
+	if !ran {
+		t.Error("Runnable should have been called.")
+	}
+}
+
+func ValueOrElse_test(t *testing.T) {
+	defer shouldNotPanic("optional.Value.OrElse", t)
+
+	if v := op.ValueOf(TEST_INT).OrElse(TEST_OTHER); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if v := op.ValueEmpty().OrElse(TEST_OTHER); v != TEST_OTHER {
+		t.Errorf("Expected `%v`, got `%v`", TEST_OTHER, v)
+	}
+	if v := op.ValueEmpty().OrElseGet(func(ts op.Ts) op.T {
+		return ts[0]
+	}, TEST_STR); v != TEST_STR {
+		t.Errorf("Expected `%v`, got `%v`", TEST_STR, v)
+	}
+}
+
+func ValueOrElsePanic_test(t *testing.T) {
+	defer shouldPanic("optional.Value.OrElsePanic", t)
+
+	if v := op.ValueOf(TEST_INT).OrElsePanic(TEST_PANIC); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	op.ValueEmpty().OrElsePanic(TEST_PANIC)
+	t.Fatal("this code should not be reachable.")
+}
@@ -0,0 +1,93 @@
+package optional
+
+import (
+	"encoding/xml"
+	"reflect"
+)
+
+// The namespace of the xsi:nil attribute.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// struct XMLNilOptional is an Optional whose empty value is marshaled as an
+// element with an xsi:nil="true" attribute rather than omitted:
+//
+//	type Partner struct {
+//		Email op.XMLNilOptional `xml:"email"`
+//	}
+//
+// It decodes as Optional does.
+type XMLNilOptional struct {
+	Optional
+}
+
+// Implements xml.Marshaler.
+//
+// A present value is encoded as the element, as if the Optional were not
+// there. An empty Optional is omitted; see XMLNilOptional to render it as
+// xsi:nil instead.
+func (o *Optional) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if o.present {
+		return e.EncodeElement(o.t, start)
+	}
+	return nil
+}
+
+// Implements xml.Marshaler.
+//
+// A present value is encoded as Optional encodes it. An empty Optional is
+// rendered as an empty element with an xsi:nil="true" attribute.
+func (o XMLNilOptional) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if o.present {
+		return o.Optional.MarshalXML(e, start)
+	}
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+		xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"})
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// Implements xml.Unmarshaler.
+//
+// An element with xsi:nil="true" empties the Optional, any other element is
+// decoded into the Optional's type (see EmptyOf).
+func (o *Optional) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && attr.Value == "true" &&
+			(attr.Name.Space == xsiNamespace || attr.Name.Space == "xsi") {
+			o.set(nil, false)
+			return d.Skip()
+		}
+	}
+	v := reflect.New(o.elemType())
+	if err := d.DecodeElement(v.Interface(), &start); err != nil {
+		return err
+	}
+	o.set(v.Elem().Interface(), true)
+	return nil
+}
+
+// Implements xml.MarshalerAttr.
+//
+// An empty Optional is omitted. A present value is encoded with its own
+// MarshalXMLAttr method if it has one, otherwise as MarshalText does.
+func (o *Optional) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !o.present {
+		return xml.Attr{}, nil
+	}
+	if m, ok := o.t.(xml.MarshalerAttr); ok {
+		return m.MarshalXMLAttr(name)
+	}
+	text, err := o.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// Implements xml.UnmarshalerAttr by decoding the value as UnmarshalText does.
+func (o *Optional) UnmarshalXMLAttr(attr xml.Attr) error {
+	return o.UnmarshalText([]byte(attr.Value))
+}
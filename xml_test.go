@@ -0,0 +1,137 @@
+package optional_test
+
+import (
+	"encoding/xml"
+	op "github.com/MercuryThePlanet/optional"
+	"testing"
+)
+
+type Partner struct {
+	XMLName xml.Name     `xml:"partner"`
+	ID      *op.Optional `xml:"id,attr"`
+	Name    *op.Optional `xml:"name"`
+	Email   *op.Optional `xml:"email"`
+	Address *op.Optional `xml:"address"`
+}
+
+type Contact struct {
+	XMLName xml.Name          `xml:"contact"`
+	Email   op.XMLNilOptional `xml:"email"`
+	Phone   *op.Optional      `xml:"phone"`
+}
+
+type Address struct {
+	City string `xml:"city"`
+}
+
+func Test_MarshalXML(t *testing.T) {
+	t.Run("MarshalXML", MarshalXML_test)
+	t.Run("MarshalXML xsi:nil", MarshalXMLNil_test)
+}
+
+func MarshalXML_test(t *testing.T) {
+	defer shouldNotPanic("optional.MarshalXML", t)
+
+	p := Partner{ID: op.Of(TEST_INT), Name: op.Of("acme"), Email: op.Empty(),
+		Address: op.Of(Address{"Oslo"})}
+	b, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<partner id="123"><name>acme</name>` +
+		`<address><city>Oslo</city></address></partner>`
+	if string(b) != expected {
+		t.Errorf("Expected `%v`, got `%v`", expected, string(b))
+	}
+
+	b, err = xml.Marshal(Partner{ID: op.Empty(), Name: op.Of("acme")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<partner><name>acme</name></partner>`; string(b) != expected {
+		t.Errorf("Expected `%v`, got `%v`", expected, string(b))
+	}
+}
+
+func MarshalXMLNil_test(t *testing.T) {
+	defer shouldNotPanic("optional.XMLNilOptional.MarshalXML", t)
+
+	b, err := xml.Marshal(Contact{Phone: op.Empty()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<contact><email xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` +
+		` xsi:nil="true"></email></contact>`
+	if string(b) != expected {
+		t.Errorf("Expected `%v`, got `%v`", expected, string(b))
+	}
+
+	b, err = xml.Marshal(Contact{Email: op.XMLNilOptional{Optional: *op.Of("a@example.com")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<contact><email>a@example.com</email></contact>`; string(b) != expected {
+		t.Errorf("Expected `%v`, got `%v`", expected, string(b))
+	}
+}
+
+func Test_UnmarshalXML(t *testing.T) {
+	t.Run("UnmarshalXML", UnmarshalXML_test)
+	t.Run("UnmarshalXML xsi:nil", UnmarshalXMLNil_test)
+	t.Run("UnmarshalXML round trip", UnmarshalXMLRoundTrip_test)
+}
+
+func UnmarshalXML_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalXML", t)
+
+	p := Partner{ID: op.EmptyOf(0), Address: op.EmptyOf(Address{})}
+	data := `<partner id="123"><name>acme</name>` +
+		`<address><city>Oslo</city></address></partner>`
+	if err := xml.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	if v := p.ID.Get(); v != TEST_INT {
+		t.Errorf("Expected `%v`, got `%v`", TEST_INT, v)
+	}
+	if v := p.Name.Get(); v != "acme" {
+		t.Errorf("Expected `%v`, got `%v`", "acme", v)
+	}
+	if v := p.Address.Get(); v != (Address{"Oslo"}) {
+		t.Errorf("Expected `%v`, got `%v`", Address{"Oslo"}, v)
+	}
+	if p.Email != nil {
+		t.Error("Missing elements should not be decoded.")
+	}
+}
+
+func UnmarshalXMLNil_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalXML", t)
+
+	p := Partner{Email: op.Of("a@example.com")}
+	data := `<partner xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<email xsi:nil="true"/></partner>`
+	if err := xml.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Email.IsPresent() {
+		t.Error("Optional should be empty.")
+	}
+}
+
+func UnmarshalXMLRoundTrip_test(t *testing.T) {
+	defer shouldNotPanic("optional.UnmarshalXML", t)
+
+	b, err := xml.Marshal(Contact{Phone: op.Of(TEST_INT)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := Contact{Email: op.XMLNilOptional{Optional: *op.Of("a@example.com")},
+		Phone: op.EmptyOf(0)}
+	if err := xml.Unmarshal(b, &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Phone.Get() != TEST_INT || c.Email.IsPresent() {
+		t.Errorf("Round trip of `%v` lost values: %v, %v", string(b),
+			c.Phone.Get(), c.Email.Get())
+	}
+}